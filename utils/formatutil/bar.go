@@ -0,0 +1,212 @@
+package formatutil
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+const (
+	// rateSampleInterval is the minimum delay between two rate samples
+	rateSampleInterval = 200 * time.Millisecond
+	// rateSmoothing is the weight of the newest sample in the moving average rate
+	rateSmoothing = 0.3
+)
+
+// CounterFormatter format the counters and the rate of a Bar
+type CounterFormatter func(n float64) string
+
+// Bar is a stateful progress bar measuring elapsed time, throughput and ETA, it is safe for concurrent use
+type Bar struct {
+	mu         sync.Mutex
+	name       string
+	current    int
+	total      int
+	start      time.Time
+	lastSample time.Time
+	lastValue  int
+	rate       float64
+	sampled    bool
+	style      BarStyle
+	values     map[string]string
+	format     CounterFormatter
+	now        func() time.Time
+}
+
+// NewBar create a progress bar started now
+func NewBar(name string, total int) *Bar {
+	style := DefaultBarStyle
+	style.Template = StatsBarTemplate
+	b := &Bar{name: name, total: total, style: style, values: make(map[string]string), now: time.Now}
+	b.start = b.now()
+	b.lastSample = b.start
+	return b
+}
+
+// Increment increase the progress by one
+func (b *Bar) Increment() {
+	b.Add(1)
+}
+
+// Add increase the progress by n
+func (b *Bar) Add(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current += n
+	b.sample()
+}
+
+// SetCurrent set the progress to current
+func (b *Bar) SetCurrent(current int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.current = current
+	b.sample()
+}
+
+// SetTotal set the total of the bar
+func (b *Bar) SetTotal(total int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.total = total
+}
+
+// SetStyle set the style used by String, StatsBarTemplate is used if style has no template
+func (b *Bar) SetStyle(style BarStyle) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if style.Template == "" {
+		style.Template = StatsBarTemplate
+	}
+	b.style = style
+}
+
+// SetFormatter set the formatter of {current}, {total} and {rate}, exp: human readable bytes
+func (b *Bar) SetFormatter(format CounterFormatter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.format = format
+}
+
+// SetValue set the value of a custom template placeholder
+// exp: SetValue("file", "a.txt") fill "{file}"
+func (b *Bar) SetValue(key, value string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.values[key] = value
+}
+
+// Current return the current progress
+func (b *Bar) Current() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current
+}
+
+// Total return the total of the bar
+func (b *Bar) Total() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total
+}
+
+// Elapsed return the time since the bar started
+func (b *Bar) Elapsed() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.now().Sub(b.start)
+}
+
+// Rate return the smoothed throughput in items per second
+func (b *Bar) Rate() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentRate()
+}
+
+// ETA return the estimated time remaining, 0 if it can not be estimated
+func (b *Bar) ETA() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.eta()
+}
+
+// String render the bar with its style, by default with percentage, counters, rate, ETA and elapsed time
+// exp: "task: [█████     ] 50% (50/100) 10.0/s ETA 5s elapsed 5s"
+func (b *Bar) String() string {
+	return b.render(ColorTrue)
+}
+
+// render render the bar with colors downgraded to level
+func (b *Bar) render(level ColorLevel) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	eta := "--"
+	if d := b.eta(); d > 0 || (b.total > 0 && b.current >= b.total) {
+		eta = FormatDuration(roundDuration(d))
+	}
+	elapsed := b.now().Sub(b.start)
+	frames := SpinnerDots
+	if b.style.ASCII {
+		frames = SpinnerLine
+	}
+	values := map[string]string{
+		"rate":    fmt.Sprintf("%.1f/s", b.currentRate()),
+		"eta":     eta,
+		"elapsed": FormatDuration(roundDuration(elapsed)),
+		"spinner": frames[int(elapsed/DefaultSpinnerInterval)%len(frames)],
+	}
+	if b.format != nil {
+		values["current"] = b.format(float64(b.current))
+		values["total"] = b.format(float64(b.total))
+		values["rate"] = b.format(b.currentRate()) + "/s"
+	}
+	maps.Copy(values, b.values)
+	return b.style.render(b.name, b.current, b.total, values, level)
+}
+
+// sample update the moving average rate, must be called with b.mu held
+func (b *Bar) sample() {
+	now := b.now()
+	dt := now.Sub(b.lastSample)
+	if dt < rateSampleInterval {
+		return
+	}
+	instant := float64(b.current-b.lastValue) / dt.Seconds()
+	if b.sampled {
+		b.rate = rateSmoothing*instant + (1-rateSmoothing)*b.rate
+	} else {
+		b.rate = instant
+		b.sampled = true
+	}
+	b.lastSample = now
+	b.lastValue = b.current
+}
+
+// currentRate must be called with b.mu held
+func (b *Bar) currentRate() float64 {
+	if b.sampled {
+		return b.rate
+	}
+	// fall back to the average rate until the first sample is taken
+	elapsed := b.now().Sub(b.start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(b.current) / elapsed
+}
+
+// eta must be called with b.mu held
+func (b *Bar) eta() time.Duration {
+	rate := b.currentRate()
+	remaining := b.total - b.current
+	if b.total <= 0 || rate <= 0 || remaining <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / rate * float64(time.Second))
+}
+
+func roundDuration(d time.Duration) time.Duration {
+	return d.Round(time.Second)
+}
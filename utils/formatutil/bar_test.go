@@ -0,0 +1,78 @@
+package formatutil
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestBar(name string, total int, clock *time.Time) *Bar {
+	b := NewBar(name, total)
+	b.now = func() time.Time { return *clock }
+	b.start = *clock
+	b.lastSample = *clock
+	return b
+}
+
+func TestBarRateAndETA(t *testing.T) {
+	clock := time.Unix(0, 0)
+	b := newTestBar("task", 100, &clock)
+
+	clock = clock.Add(time.Second)
+	b.Add(10)
+	if got := b.Rate(); math.Abs(got-10) > 1e-9 {
+		t.Fatalf("expected rate 10/s after first sample, got %v", got)
+	}
+	if got := b.ETA(); got != 9*time.Second {
+		t.Fatalf("expected ETA 9s, got %v", got)
+	}
+
+	clock = clock.Add(time.Second)
+	b.Add(20)
+	// 0.3*20 + 0.7*10
+	if got := b.Rate(); math.Abs(got-13) > 1e-9 {
+		t.Fatalf("expected smoothed rate 13/s, got %v", got)
+	}
+	if got := b.Elapsed(); got != 2*time.Second {
+		t.Fatalf("expected elapsed 2s, got %v", got)
+	}
+}
+
+func TestBarAverageRateBeforeFirstSample(t *testing.T) {
+	clock := time.Unix(0, 0)
+	b := newTestBar("task", 100, &clock)
+	b.Add(5)
+	clock = clock.Add(100 * time.Millisecond)
+	if got := b.Rate(); math.Abs(got-50) > 1e-9 {
+		t.Fatalf("expected average rate 50/s, got %v", got)
+	}
+}
+
+func TestBarString(t *testing.T) {
+	clock := time.Unix(0, 0)
+	b := newTestBar("task", 0, &clock)
+	if got := b.String(); !strings.Contains(got, "ETA --") {
+		t.Fatalf("expected unknown ETA for zero total, got %q", got)
+	}
+
+	b.SetTotal(4)
+	clock = clock.Add(2 * time.Second)
+	b.Add(2)
+	got := b.String()
+	if !strings.HasPrefix(got, "task: [") || !strings.Contains(got, "50% (2/4)") {
+		t.Fatalf("expected percentage and counters, got %q", got)
+	}
+	if !strings.Contains(got, "1.0/s ETA 2s elapsed 2s") {
+		t.Fatalf("expected rate, ETA and elapsed time, got %q", got)
+	}
+
+	b.Increment()
+	b.SetCurrent(4)
+	if got := b.String(); !strings.Contains(got, "ETA 0s") {
+		t.Fatalf("expected zero ETA on completion, got %q", got)
+	}
+	if b.Current() != 4 || b.Total() != 4 {
+		t.Fatalf("unexpected counters %d/%d", b.Current(), b.Total())
+	}
+}
@@ -0,0 +1,345 @@
+package formatutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Color is one of the 16 basic terminal colors
+type Color uint8
+
+// basic terminal colors
+const (
+	Black Color = iota
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	BrightBlack
+	BrightRed
+	BrightGreen
+	BrightYellow
+	BrightBlue
+	BrightMagenta
+	BrightCyan
+	BrightWhite
+)
+
+// ColorLevel is the color support of a terminal
+type ColorLevel int
+
+// color support levels
+const (
+	// ColorNone means no ANSI sequence is written
+	ColorNone ColorLevel = iota
+	// ColorBasic supports the 16 basic colors
+	ColorBasic
+	// Color256 supports the 256 color palette
+	Color256
+	// ColorTrue supports 24-bit RGB colors
+	ColorTrue
+)
+
+type colorMode uint8
+
+const (
+	modeNone colorMode = iota
+	modeBasic
+	mode256
+	modeRGB
+)
+
+// colorSpec is a foreground or background color, value is a Color, a palette index or 0xRRGGBB
+type colorSpec struct {
+	mode  colorMode
+	value uint32
+}
+
+type attribute uint8
+
+const (
+	attrBold attribute = 1 << iota
+	attrDim
+	attrItalic
+	attrUnderline
+	attrBlink
+	attrReverse
+	attrStrikethrough
+)
+
+// attributes in SGR code order
+var attributeCodes = []struct {
+	attr attribute
+	code string
+}{
+	{attrBold, "1"}, {attrDim, "2"}, {attrItalic, "3"}, {attrUnderline, "4"},
+	{attrBlink, "5"}, {attrReverse, "7"}, {attrStrikethrough, "9"},
+}
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// Style is an immutable set of colors and text attributes, the zero value renders text unchanged
+// exp: NewStyle().Fg(Red).Bold().Sprint("error")
+type Style struct {
+	fg, bg colorSpec
+	attrs  attribute
+}
+
+// NewStyle create an empty style
+func NewStyle() Style {
+	return Style{}
+}
+
+// Fg set a basic foreground color
+func (s Style) Fg(c Color) Style {
+	s.fg = colorSpec{mode: modeBasic, value: uint32(c & 0x0f)}
+	return s
+}
+
+// Bg set a basic background color
+func (s Style) Bg(c Color) Style {
+	s.bg = colorSpec{mode: modeBasic, value: uint32(c & 0x0f)}
+	return s
+}
+
+// Fg256 set a foreground color of the 256 color palette
+func (s Style) Fg256(index uint8) Style {
+	s.fg = colorSpec{mode: mode256, value: uint32(index)}
+	return s
+}
+
+// Bg256 set a background color of the 256 color palette
+func (s Style) Bg256(index uint8) Style {
+	s.bg = colorSpec{mode: mode256, value: uint32(index)}
+	return s
+}
+
+// FgRGB set a 24-bit foreground color
+func (s Style) FgRGB(r, g, b uint8) Style {
+	s.fg = colorSpec{mode: modeRGB, value: uint32(r)<<16 | uint32(g)<<8 | uint32(b)}
+	return s
+}
+
+// BgRGB set a 24-bit background color
+func (s Style) BgRGB(r, g, b uint8) Style {
+	s.bg = colorSpec{mode: modeRGB, value: uint32(r)<<16 | uint32(g)<<8 | uint32(b)}
+	return s
+}
+
+// Bold set bold text
+func (s Style) Bold() Style { return s.with(attrBold) }
+
+// Dim set faint text
+func (s Style) Dim() Style { return s.with(attrDim) }
+
+// Italic set italic text
+func (s Style) Italic() Style { return s.with(attrItalic) }
+
+// Underline set underlined text
+func (s Style) Underline() Style { return s.with(attrUnderline) }
+
+// Blink set blinking text
+func (s Style) Blink() Style { return s.with(attrBlink) }
+
+// Reverse swap foreground and background colors
+func (s Style) Reverse() Style { return s.with(attrReverse) }
+
+// Strikethrough set crossed out text
+func (s Style) Strikethrough() Style { return s.with(attrStrikethrough) }
+
+// IsZero check if the style has no color nor attribute
+func (s Style) IsZero() bool {
+	return s == Style{}
+}
+
+// Sprint format a like fmt.Sprint and wrap it with the style, assuming true color support
+func (s Style) Sprint(a ...interface{}) string {
+	return s.render(fmt.Sprint(a...), ColorTrue)
+}
+
+// Sprintf format like fmt.Sprintf and wrap it with the style, assuming true color support
+func (s Style) Sprintf(format string, a ...interface{}) string {
+	return s.render(fmt.Sprintf(format, a...), ColorTrue)
+}
+
+// Fprint write a styled like fmt.Fprint, colors are downgraded or dropped according to DetectColorLevel(w)
+func (s Style) Fprint(w io.Writer, a ...interface{}) (int, error) {
+	return io.WriteString(w, s.render(fmt.Sprint(a...), DetectColorLevel(w)))
+}
+
+// Fprintf write styled text like fmt.Fprintf, colors are downgraded or dropped according to DetectColorLevel(w)
+func (s Style) Fprintf(w io.Writer, format string, a ...interface{}) (int, error) {
+	return io.WriteString(w, s.render(fmt.Sprintf(format, a...), DetectColorLevel(w)))
+}
+
+// ColorEnabled check if colors should be written to w.
+// NO_COLOR (non-empty) disables colors, a non-empty FORCE_COLOR enables them unless set to "0" or "false",
+// otherwise colors are enabled for terminals other than TERM=dumb.
+func ColorEnabled(w io.Writer) bool {
+	if v := os.Getenv("NO_COLOR"); v != "" {
+		return false
+	}
+	if v := os.Getenv("FORCE_COLOR"); v != "" {
+		return v != "0" && !strings.EqualFold(v, "false")
+	}
+	return os.Getenv("TERM") != "dumb" && IsTerminal(w)
+}
+
+// DetectColorLevel return the color support of w,
+// COLORTERM=truecolor|24bit means ColorTrue and a TERM containing "256color" means Color256
+func DetectColorLevel(w io.Writer) ColorLevel {
+	if !ColorEnabled(w) {
+		return ColorNone
+	}
+	switch v := os.Getenv("FORCE_COLOR"); v {
+	case "1":
+		return ColorBasic
+	case "2":
+		return Color256
+	case "3":
+		return ColorTrue
+	}
+	if ct := strings.ToLower(os.Getenv("COLORTERM")); ct == "truecolor" || ct == "24bit" {
+		return ColorTrue
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Color256
+	}
+	return ColorBasic
+}
+
+// outputColorLevel return the level progress renderers draw at on w when colors are enabled,
+// ColorTrue when colors are forced on a writer without detected support
+func outputColorLevel(w io.Writer) ColorLevel {
+	if level := DetectColorLevel(w); level != ColorNone {
+		return level
+	}
+	return ColorTrue
+}
+
+// StripANSI remove ANSI escape sequences from s
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+func (s Style) with(a attribute) Style {
+	s.attrs |= a
+	return s
+}
+
+// render wrap text with SGR sequences supported by level
+func (s Style) render(text string, level ColorLevel) string {
+	if level == ColorNone || s.IsZero() || text == "" {
+		return text
+	}
+	var codes []string
+	for _, ac := range attributeCodes {
+		if s.attrs&ac.attr != 0 {
+			codes = append(codes, ac.code)
+		}
+	}
+	if c := s.fg.code(level, false); c != "" {
+		codes = append(codes, c)
+	}
+	if c := s.bg.code(level, true); c != "" {
+		codes = append(codes, c)
+	}
+	if len(codes) == 0 {
+		return text
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m" + text + "\x1b[0m"
+}
+
+// code return the SGR parameters of the color, downgraded to level
+func (c colorSpec) code(level ColorLevel, background bool) string {
+	mode, value := c.mode, c.value
+	if mode == modeRGB && level < ColorTrue {
+		mode, value = mode256, uint32(rgbTo256(value))
+	}
+	if mode == mode256 && level < Color256 {
+		mode, value = modeBasic, uint32(paletteToBasic(uint8(value)))
+	}
+
+	prefix := "3"
+	if background {
+		prefix = "4"
+	}
+	switch mode {
+	case modeBasic:
+		if value >= 8 {
+			// bright colors use 90-97 and 100-107
+			if background {
+				return strconv.Itoa(100 + int(value) - 8)
+			}
+			return strconv.Itoa(90 + int(value) - 8)
+		}
+		return prefix + strconv.Itoa(int(value))
+	case mode256:
+		return prefix + "8;5;" + strconv.Itoa(int(value))
+	case modeRGB:
+		return fmt.Sprintf("%s8;2;%d;%d;%d", prefix, value>>16&0xff, value>>8&0xff, value&0xff)
+	}
+	return ""
+}
+
+// rgbTo256 map a 0xRRGGBB color to the 6x6x6 cube of the 256 color palette
+func rgbTo256(rgb uint32) uint8 {
+	r, g, b := rgb>>16&0xff, rgb>>8&0xff, rgb&0xff
+	if r == g && g == b {
+		// use the grayscale ramp 232-255 for grays
+		if r < 8 {
+			return 16
+		}
+		if r > 248 {
+			return 231
+		}
+		return uint8(232 + (r-8)*24/247)
+	}
+	scale := func(v uint32) uint32 { return (v*5 + 127) / 255 }
+	return uint8(16 + 36*scale(r) + 6*scale(g) + scale(b))
+}
+
+// paletteToBasic map a 256 color palette index to the nearest basic color
+func paletteToBasic(index uint8) Color {
+	switch {
+	case index < 16:
+		return Color(index)
+	case index >= 232:
+		gray := index - 232
+		switch {
+		case gray < 6:
+			return Black
+		case gray < 12:
+			return BrightBlack
+		case gray < 18:
+			return White
+		}
+		return BrightWhite
+	}
+	i := index - 16
+	r, g, b := i/36, i/6%6, i%6
+	c := Color(0)
+	if r >= 3 {
+		c |= Red
+	}
+	if g >= 3 {
+		c |= Green
+	}
+	if b >= 3 {
+		c |= Blue
+	}
+	if r >= 5 || g >= 5 || b >= 5 {
+		c += BrightBlack
+	}
+	return c
+}
@@ -0,0 +1,153 @@
+package formatutil
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStyleSprint(t *testing.T) {
+	tests := []struct {
+		name  string
+		style Style
+		want  string
+	}{
+		{name: "zero", style: NewStyle(), want: "text"},
+		{name: "basic", style: NewStyle().Fg(Red), want: "\x1b[31mtext\x1b[0m"},
+		{name: "bright", style: NewStyle().Fg(BrightGreen).Bg(BrightBlack), want: "\x1b[92;100mtext\x1b[0m"},
+		{name: "attributes", style: NewStyle().Underline().Bold(), want: "\x1b[1;4mtext\x1b[0m"},
+		{name: "palette", style: NewStyle().Fg256(208).Bg(Blue), want: "\x1b[38;5;208;44mtext\x1b[0m"},
+		{name: "truecolor", style: NewStyle().FgRGB(255, 128, 0).BgRGB(1, 2, 3), want: "\x1b[38;2;255;128;0;48;2;1;2;3mtext\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Sprint("text"); got != tt.want {
+				t.Fatalf("Sprint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyleDowngrade(t *testing.T) {
+	style := NewStyle().FgRGB(255, 0, 0)
+	if got := style.render("x", Color256); got != "\x1b[38;5;196mx\x1b[0m" {
+		t.Fatalf("expected 256 color downgrade, got %q", got)
+	}
+	if got := style.render("x", ColorBasic); got != "\x1b[91mx\x1b[0m" {
+		t.Fatalf("expected basic color downgrade, got %q", got)
+	}
+	if got := style.render("x", ColorNone); got != "x" {
+		t.Fatalf("expected no sequence, got %q", got)
+	}
+}
+
+func TestColorDetection(t *testing.T) {
+	var buf bytes.Buffer
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("COLORTERM", "")
+	t.Setenv("TERM", "xterm-256color")
+	if ColorEnabled(&buf) {
+		t.Fatalf("expected an empty FORCE_COLOR to have no effect for a non terminal writer")
+	}
+
+	t.Setenv("FORCE_COLOR", "1")
+	if !ColorEnabled(&buf) {
+		t.Fatalf("expected FORCE_COLOR to enable colors for a non terminal writer")
+	}
+	if got := DetectColorLevel(&buf); got != ColorBasic {
+		t.Fatalf("expected ColorBasic, got %v", got)
+	}
+
+	t.Setenv("FORCE_COLOR", "true")
+	if got := DetectColorLevel(&buf); got != Color256 {
+		t.Fatalf("expected Color256, got %v", got)
+	}
+
+	t.Setenv("FORCE_COLOR", "3")
+	if got := DetectColorLevel(&buf); got != ColorTrue {
+		t.Fatalf("expected ColorTrue, got %v", got)
+	}
+
+	t.Setenv("FORCE_COLOR", "0")
+	if ColorEnabled(&buf) {
+		t.Fatalf("expected FORCE_COLOR=0 to disable colors")
+	}
+
+	t.Setenv("FORCE_COLOR", "1")
+	t.Setenv("NO_COLOR", "1")
+	if ColorEnabled(&buf) {
+		t.Fatalf("expected NO_COLOR to disable colors")
+	}
+	if _, err := NewStyle().Fg(Red).Fprint(&buf, "plain"); err != nil || buf.String() != "plain" {
+		t.Fatalf("expected plain output when colors are disabled, got %q, err %v", buf.String(), err)
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain", want: "plain"},
+		{in: "\x1b[1;31mred\x1b[0m", want: "red"},
+		{in: "\x1b[2K\x1b[3Aline", want: "line"},
+		{in: "\x1b]8;;http://x\x07link\x1b]8;;\x07", want: "link"},
+	}
+	for _, tt := range tests {
+		if got := StripANSI(tt.in); got != tt.want {
+			t.Fatalf("StripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestColoredBar(t *testing.T) {
+	style := BarStyle{Width: 4, Fill: "#", Empty: "-", Template: "{bar}", FillColor: NewStyle().Fg(Green)}
+	if got := style.Render("task", 1, 2); got != "\x1b[32m##\x1b[0m--" {
+		t.Fatalf("unexpected colored bar %q", got)
+	}
+
+	var buf bytes.Buffer
+	p := NewMultiProgress(&buf)
+	p.SetColor(false)
+	p.SetStyle(style)
+	p.Add("task", 2)
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Fatalf("expected colors dropped for a writer without color, got %q", buf.String())
+	}
+
+	// true colors are downgraded to the level of the writer
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "2")
+	rgb := BarStyle{Width: 2, Fill: "#", Empty: "-", Template: "{bar}", FillColor: NewStyle().FgRGB(255, 0, 0)}
+	buf.Reset()
+	p = NewMultiProgress(&buf)
+	p.SetStyle(rgb)
+	p.Add("task", 2)
+	p.Set("task", 1)
+	p.Stop()
+	if got := buf.String(); !strings.HasSuffix(got, "\x1b[38;5;196m#\x1b[0m-\n") || strings.Contains(got, "38;2;") {
+		t.Fatalf("expected 256 color bar, got %q", got)
+	}
+
+	t.Setenv("FORCE_COLOR", "1")
+	buf.Reset()
+	w := NewProgressWriter(io.Discard, "task", 2, &buf)
+	w.Bar().SetStyle(rgb)
+	_, _ = w.Write([]byte("x"))
+	_ = w.Close()
+	if got := buf.String(); !strings.Contains(got, "\x1b[91m#\x1b[0m") || strings.Contains(got, "38;2;") {
+		t.Fatalf("expected basic color bar, got %q", got)
+	}
+}
+
+func TestTableIgnoreANSIWidth(t *testing.T) {
+	table := NewTable("a")
+	table.AddRow(NewStyle().Fg(Red).Sprint("x"))
+	if got, want := table.String(), "a\n\x1b[31mx\x1b[0m\n"; got != want {
+		t.Fatalf("unexpected table %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,318 @@
+package formatutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ByteUnit is the unit system of byte sizes
+type ByteUnit int
+
+// unit systems of byte sizes
+const (
+	// IEC use powers of 1024: KiB, MiB, GiB...
+	IEC ByteUnit = iota
+	// SI use powers of 1000: kB, MB, GB...
+	SI
+)
+
+const day = 24 * time.Hour
+
+var (
+	iecPrefixes     = []string{"Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+	siPrefixes      = []string{"k", "M", "G", "T", "P", "E"}
+	compactSuffixes = []string{"k", "M", "B", "T"}
+)
+
+// FormatBytes format n bytes with IEC units and one decimal
+// exp: 1536 -> "1.5 KiB", 512 -> "512 B"
+func FormatBytes(n int64) string {
+	return FormatBytesWith(n, IEC, 1)
+}
+
+// FormatBytesWith format n bytes with unit system and precision decimals
+// exp: FormatBytesWith(1500, SI, 2) -> "1.50 kB"
+func FormatBytesWith(n int64, unit ByteUnit, precision int) string {
+	base, prefixes := 1024.0, iecPrefixes
+	if unit == SI {
+		base, prefixes = 1000.0, siPrefixes
+	}
+	sign := ""
+	v := float64(n)
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	if v < base {
+		return fmt.Sprintf("%s%d B", sign, int64(v))
+	}
+	exp := -1
+	for v >= base && exp < len(prefixes)-1 {
+		v /= base
+		exp++
+	}
+	precision = max(precision, 0)
+	// move up one unit when rounding reach the base, exp: 1048575 -> "1.0 MiB" rather than "1024.0 KiB"
+	if r, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'f', precision, 64), 64); r >= base && exp < len(prefixes)-1 {
+		v /= base
+		exp++
+	}
+	return fmt.Sprintf("%s%.*f %sB", sign, precision, v, prefixes[exp])
+}
+
+// ParseBytes parse a human readable byte size, units are case insensitive,
+// "k"/"kB" are powers of 1000 and "Ki"/"KiB" powers of 1024
+// exp: "10MiB" -> 10485760, "1.5 kb" -> 1500, "42" -> 42
+func ParseBytes(s string) (int64, error) {
+	num, unit := splitNumber(strings.TrimSpace(s))
+	if num == "" {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+
+	unit = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(unit)), "b")
+	multiplier := 1.0
+	if unit != "" {
+		base := 1000.0
+		if strings.HasSuffix(unit, "i") {
+			base, unit = 1024, strings.TrimSuffix(unit, "i")
+		}
+		exp := strings.Index("kmgtpe", unit)
+		if len(unit) != 1 || exp < 0 {
+			return 0, fmt.Errorf("unknown byte unit in %q", s)
+		}
+		multiplier = math.Pow(base, float64(exp+1))
+	}
+
+	v = math.Round(v * multiplier)
+	if v >= math.MaxInt64 || v <= math.MinInt64 {
+		return 0, fmt.Errorf("byte size %q overflows int64", s)
+	}
+	return int64(v), nil
+}
+
+// FormatDuration format d with day, hour, minute and second components, zero components are omitted,
+// durations under one second keep the time.Duration format
+// exp: 3792*time.Second -> "1h 3m 12s", 26*time.Hour -> "1d 2h", 1500*time.Millisecond -> "1s"
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	if d < time.Second {
+		return sign + d.String()
+	}
+
+	var parts []string
+	for _, u := range []struct {
+		size   time.Duration
+		suffix string
+	}{{day, "d"}, {time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}} {
+		if n := d / u.size; n > 0 {
+			parts = append(parts, strconv.FormatInt(int64(n), 10)+u.suffix)
+			d -= n * u.size
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// FormatRelative format t relative to now
+// exp: "just now", "3 days ago", "in 2 hours"
+func FormatRelative(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Minute {
+		return "just now"
+	}
+
+	var n int64
+	var unit string
+	switch {
+	case d < time.Hour:
+		n, unit = int64(d/time.Minute), "minute"
+	case d < day:
+		n, unit = int64(d/time.Hour), "hour"
+	case d < 30*day:
+		n, unit = int64(d/day), "day"
+	case d < 365*day:
+		n, unit = int64(d/(30*day)), "month"
+	default:
+		n, unit = int64(d/(365*day)), "year"
+	}
+	if n > 1 {
+		unit += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+// TimeAgo format t relative to the current time
+func TimeAgo(t time.Time) string {
+	return FormatRelative(t, time.Now())
+}
+
+// ParseDuration parse a duration like time.ParseDuration, also accepting spaces between components,
+// "d" for days and "w" for weeks
+// exp: "1.5h" -> 90m, "1h 3m 12s" -> 1h3m12s, "2d" -> 48h
+func ParseDuration(s string) (time.Duration, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", orig)
+	}
+	if s == "0" {
+		return 0, nil
+	}
+
+	var total float64
+	for s != "" {
+		s = strings.TrimLeft(s, " ")
+		num, rest := splitNumber(s)
+		if num == "" {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+		v, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		}
+		i := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsLetter(r) })
+		if i < 0 {
+			i = len(rest)
+		}
+		unit, ok := durationUnits[rest[:i]]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q in duration %q", rest[:i], orig)
+		}
+		total += v * float64(unit)
+		s = rest[i:]
+	}
+	if total >= math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q overflows", orig)
+	}
+	if neg {
+		total = -total
+	}
+	return time.Duration(total), nil
+}
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"μs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  day,
+	"w":  7 * day,
+}
+
+// FormatNumber format n with "," as thousand separator
+// exp: 1234567 -> "1,234,567"
+func FormatNumber(n int64) string {
+	return FormatNumberSep(n, ",")
+}
+
+// FormatNumberSep format n with sep as thousand separator
+// exp: FormatNumberSep(1234567, " ") -> "1 234 567"
+func FormatNumberSep(n int64, sep string) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// FormatCompact format n in compact notation with one decimal
+// exp: 999 -> "999", 1200 -> "1.2k", 3400000 -> "3.4M", 5000000000 -> "5B"
+func FormatCompact(n int64) string {
+	v := math.Abs(float64(n))
+	if v < 1000 {
+		return strconv.FormatInt(n, 10)
+	}
+	exp := -1
+	for v >= 1000 && exp < len(compactSuffixes)-1 {
+		v /= 1000
+		exp++
+	}
+	// rounding may carry over to the next suffix, exp: 999950 -> "1M"
+	if math.Round(v*10)/10 >= 1000 && exp < len(compactSuffixes)-1 {
+		v /= 1000
+		exp++
+	}
+	s := strings.TrimSuffix(strconv.FormatFloat(v, 'f', 1, 64), ".0")
+	if n < 0 {
+		s = "-" + s
+	}
+	return s + compactSuffixes[exp]
+}
+
+// ParseNumber parse a number written with thousand separators or in compact notation
+// exp: "1,234" -> 1234, "1.2k" -> 1200, "3.4M" -> 3400000
+func ParseNumber(s string) (int64, error) {
+	clean := strings.NewReplacer(",", "", "_", "", " ", "").Replace(strings.TrimSpace(s))
+	if clean == "" {
+		return 0, errors.New("invalid number \"\"")
+	}
+	multiplier := 1.0
+	suffix := strings.ToLower(clean[len(clean)-1:])
+	for i, c := range compactSuffixes {
+		if strings.ToLower(c) == suffix {
+			multiplier = math.Pow(1000, float64(i+1))
+			clean = clean[:len(clean)-1]
+			break
+		}
+	}
+	if multiplier == 1 {
+		n, err := strconv.ParseInt(clean, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+		return n, nil
+	}
+	v, err := strconv.ParseFloat(clean, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	v = math.Round(v * multiplier)
+	if v >= math.MaxInt64 || v <= math.MinInt64 {
+		return 0, fmt.Errorf("number %q overflows int64", s)
+	}
+	return int64(v), nil
+}
+
+// splitNumber split s into its leading decimal number and the rest
+func splitNumber(s string) (num, rest string) {
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+	return s[:i], s[i:]
+}
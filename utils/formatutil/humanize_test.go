@@ -0,0 +1,206 @@
+package formatutil
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        int64
+		unit      ByteUnit
+		precision int
+		want      string
+	}{
+		{name: "zero", in: 0, unit: IEC, precision: 1, want: "0 B"},
+		{name: "belowBase", in: 1023, unit: IEC, precision: 1, want: "1023 B"},
+		{name: "kibibyte", in: 1536, unit: IEC, precision: 1, want: "1.5 KiB"},
+		{name: "mebibyte", in: 5 << 20, unit: IEC, precision: 2, want: "5.00 MiB"},
+		{name: "kilobyte", in: 1500, unit: SI, precision: 2, want: "1.50 kB"},
+		{name: "gigabyte", in: 3_000_000_000, unit: SI, precision: 0, want: "3 GB"},
+		{name: "negative", in: -2048, unit: IEC, precision: 1, want: "-2.0 KiB"},
+		{name: "roundsUpToNextIEC", in: 1048575, unit: IEC, precision: 1, want: "1.0 MiB"},
+		{name: "roundsUpToNextSI", in: 999_950, unit: SI, precision: 1, want: "1.0 MB"},
+		{name: "staysBelowNextSI", in: 999_949, unit: SI, precision: 1, want: "999.9 kB"},
+		{name: "roundsUpZeroPrecision", in: 999_500, unit: SI, precision: 0, want: "1 MB"},
+		{name: "negativeRoundsUp", in: -1048575, unit: IEC, precision: 1, want: "-1.0 MiB"},
+		{name: "largestUnit", in: math.MaxInt64, unit: SI, precision: 1, want: "9.2 EB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatBytesWith(tt.in, tt.unit, tt.precision); got != tt.want {
+				t.Fatalf("FormatBytesWith(%d) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+	if got := FormatBytes(1536); got != "1.5 KiB" {
+		t.Fatalf("FormatBytes(1536) = %q", got)
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "42", want: 42},
+		{in: "10B", want: 10},
+		{in: "10MiB", want: 10 << 20},
+		{in: "1.5 kb", want: 1500},
+		{in: "2Ki", want: 2048},
+		{in: "1G", want: 1_000_000_000},
+		{in: "", wantErr: true},
+		{in: "MB", wantErr: true},
+		{in: "10XB", wantErr: true},
+		{in: "100EiB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseBytes(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseBytes(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseBytes(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{in: 0, want: "0s"},
+		{in: 500 * time.Millisecond, want: "500ms"},
+		{in: 1500 * time.Millisecond, want: "1s"},
+		{in: 3792 * time.Second, want: "1h 3m 12s"},
+		{in: 26 * time.Hour, want: "1d 2h"},
+		{in: -90 * time.Second, want: "-1m 30s"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.in); got != tt.want {
+			t.Fatalf("FormatDuration(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   time.Time
+		want string
+	}{
+		{in: now.Add(-10 * time.Second), want: "just now"},
+		{in: now.Add(-time.Minute), want: "1 minute ago"},
+		{in: now.Add(-3 * 24 * time.Hour), want: "3 days ago"},
+		{in: now.Add(2 * time.Hour), want: "in 2 hours"},
+		{in: now.AddDate(-2, 0, 0), want: "2 years ago"},
+	}
+	for _, tt := range tests {
+		if got := FormatRelative(tt.in, now); got != tt.want {
+			t.Fatalf("FormatRelative(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "1.5h", want: 90 * time.Minute},
+		{in: "1h 3m 12s", want: time.Hour + 3*time.Minute + 12*time.Second},
+		{in: "1h3m", want: time.Hour + 3*time.Minute},
+		{in: "2d", want: 48 * time.Hour},
+		{in: "1w", want: 7 * 24 * time.Hour},
+		{in: "-300ms", want: -300 * time.Millisecond},
+		{in: "", wantErr: true},
+		{in: "5", wantErr: true},
+		{in: "3y", wantErr: true},
+		{in: "9223372036854775808ns", wantErr: true},
+		{in: "-9223372036854775808ns", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{in: 0, want: "0"},
+		{in: 999, want: "999"},
+		{in: 1000, want: "1,000"},
+		{in: 1234567, want: "1,234,567"},
+		{in: -1234567, want: "-1,234,567"},
+	}
+	for _, tt := range tests {
+		if got := FormatNumber(tt.in); got != tt.want {
+			t.Fatalf("FormatNumber(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := FormatNumberSep(1234567, " "); got != "1 234 567" {
+		t.Fatalf("FormatNumberSep = %q", got)
+	}
+}
+
+func TestFormatCompact(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{in: 999, want: "999"},
+		{in: 1000, want: "1k"},
+		{in: 1200, want: "1.2k"},
+		{in: 3_400_000, want: "3.4M"},
+		{in: 999_950, want: "1M"},
+		{in: 5_000_000_000, want: "5B"},
+		{in: -1500, want: "-1.5k"},
+	}
+	for _, tt := range tests {
+		if got := FormatCompact(tt.in); got != tt.want {
+			t.Fatalf("FormatCompact(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "1,234", want: 1234},
+		{in: "-1_000", want: -1000},
+		{in: "1.2k", want: 1200},
+		{in: "3.4M", want: 3_400_000},
+		{in: "2b", want: 2_000_000_000},
+		{in: "", wantErr: true},
+		{in: "1.5", wantErr: true},
+		{in: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseNumber(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseNumber(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
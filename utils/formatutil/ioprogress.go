@@ -0,0 +1,158 @@
+package formatutil
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ProgressReader wrap an io.Reader and render the progress of the bytes read to an output writer
+type ProgressReader struct {
+	r io.Reader
+	*progressIO
+}
+
+// ProgressWriter wrap an io.Writer and render the progress of the bytes written to an output writer
+type ProgressWriter struct {
+	w io.Writer
+	*progressIO
+}
+
+// NewProgressReader wrap r, total is the expected size in bytes, not positive if unknown
+// exp:
+//
+//	pr := NewProgressReader(resp.Body, "download", resp.ContentLength, os.Stderr)
+//	defer pr.Close()
+//	io.Copy(file, pr)
+func NewProgressReader(r io.Reader, name string, total int64, out io.Writer) *ProgressReader {
+	return &ProgressReader{r: r, progressIO: newProgressIO(name, total, out)}
+}
+
+// NewProgressWriter wrap w, total is the expected size in bytes, not positive if unknown
+func NewProgressWriter(w io.Writer, name string, total int64, out io.Writer) *ProgressWriter {
+	return &ProgressWriter{w: w, progressIO: newProgressIO(name, total, out)}
+}
+
+// Read read from the wrapped reader and count the bytes read, the progress is finished on io.EOF
+func (r *ProgressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.add(n, errors.Is(err, io.EOF))
+	return n, err
+}
+
+// Close finish the progress and close the wrapped reader if it is an io.Closer
+func (r *ProgressReader) Close() error {
+	r.finish()
+	if c, ok := r.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Write write to the wrapped writer and count the bytes written
+func (w *ProgressWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.add(n, false)
+	return n, err
+}
+
+// Close finish the progress and close the wrapped writer if it is an io.Closer
+func (w *ProgressWriter) Close() error {
+	w.finish()
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+type progressIO struct {
+	mu       sync.Mutex
+	bar      *Bar
+	out      io.Writer
+	terminal bool
+	color    bool
+	level    ColorLevel
+	last     time.Time
+	finished bool
+}
+
+func newProgressIO(name string, total int64, out io.Writer) *progressIO {
+	bar := NewBar(name, int(max(total, 0)))
+	bar.SetFormatter(func(n float64) string { return FormatBytes(int64(n)) })
+	if total <= 0 {
+		bar.SetStyle(BarStyle{Template: UnknownTotalTemplate})
+	}
+	return &progressIO{bar: bar, out: out, terminal: IsTerminal(out), color: ColorEnabled(out), level: outputColorLevel(out)}
+}
+
+// Bar return the underlying progress bar
+func (p *progressIO) Bar() *Bar {
+	return p.bar
+}
+
+// SetTerminal override terminal detection of the output writer
+func (p *progressIO) SetTerminal(terminal bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.terminal = terminal
+}
+
+// SetColor override color detection of the output writer, colors of the bar style are dropped when disabled
+func (p *progressIO) SetColor(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.color = enabled
+}
+
+func (p *progressIO) add(n int, eof bool) {
+	p.bar.Add(n)
+	if eof {
+		p.finish()
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finished {
+		return
+	}
+	interval := DefaultLogInterval
+	if p.terminal {
+		interval = DefaultRefreshInterval
+	}
+	if now := p.bar.now(); now.Sub(p.last) >= interval {
+		p.render(false)
+		p.last = now
+	}
+}
+
+// finish render the final state once and end the line
+func (p *progressIO) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.finished {
+		return
+	}
+	p.finished = true
+	p.render(true)
+}
+
+// render must be called with p.mu held
+func (p *progressIO) render(final bool) {
+	var line string
+	if p.color {
+		line = p.bar.render(p.level)
+	} else {
+		line = StripANSI(p.bar.render(ColorNone))
+	}
+	switch {
+	case p.terminal && final:
+		line = "\r" + ansiClearLine + line + "\n"
+	case p.terminal:
+		line = "\r" + ansiClearLine + line
+	default:
+		line += "\n"
+	}
+	_, _ = io.WriteString(p.out, line)
+}
@@ -0,0 +1,60 @@
+package formatutil
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestProgressReader(t *testing.T) {
+	var out bytes.Buffer
+	src := strings.NewReader(strings.Repeat("x", 3*1024))
+	pr := NewProgressReader(src, "download", 3*1024, &out)
+
+	n, err := io.Copy(io.Discard, pr)
+	if err != nil || n != 3*1024 {
+		t.Fatalf("expected to copy 3072 bytes, got %d, err %v", n, err)
+	}
+	if err = pr.Close(); err != nil {
+		t.Fatalf("expected no error on close, got %v", err)
+	}
+	if got := pr.Bar().Current(); got != 3*1024 {
+		t.Fatalf("expected 3072 bytes counted, got %d", got)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	last := lines[len(lines)-1]
+	if !strings.Contains(last, "100% (3.0 KiB/3.0 KiB)") {
+		t.Fatalf("expected final line with byte units, got %q", last)
+	}
+	rendered := out.Len()
+	_ = pr.Close()
+	if out.Len() != rendered {
+		t.Fatalf("expected final state rendered once, got %q", out.String())
+	}
+}
+
+func TestProgressWriterUnknownTotal(t *testing.T) {
+	var out, dst bytes.Buffer
+	pw := NewProgressWriter(&dst, "upload", 0, &out)
+	pw.SetTerminal(true)
+
+	if _, err := pw.Write(make([]byte, 2048)); err != nil {
+		t.Fatalf("unexpected write error %v", err)
+	}
+	if err := pw.Close(); err != nil {
+		t.Fatalf("unexpected close error %v", err)
+	}
+	if dst.Len() != 2048 {
+		t.Fatalf("expected bytes written through, got %d", dst.Len())
+	}
+
+	got := out.String()
+	if !strings.HasPrefix(got, "\r\x1b[2K") || !strings.HasSuffix(got, "\n") {
+		t.Fatalf("expected in place redraw ended by a newline, got %q", got)
+	}
+	if !strings.Contains(got, "upload: ⠋ 2.0 KiB") || strings.Contains(got, "%") {
+		t.Fatalf("expected byte counter without bar for unknown total, got %q", got)
+	}
+}
@@ -0,0 +1,213 @@
+package formatutil
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRefreshInterval is the minimum delay between two redraws on a terminal
+	DefaultRefreshInterval = 100 * time.Millisecond
+	// DefaultLogInterval is the delay between two plain line logs when output is not a terminal
+	DefaultLogInterval = 5 * time.Second
+)
+
+// MultiProgress render multiple named progress bars to one writer, it is safe for concurrent use.
+// On a terminal the bars are redrawn in place as a stacked block with ANSI cursor movement,
+// otherwise the bars are logged as plain lines periodically and once when they complete.
+type MultiProgress struct {
+	mu          sync.Mutex
+	w           io.Writer
+	terminal    bool
+	color       bool
+	level       ColorLevel
+	refresh     time.Duration
+	logInterval time.Duration
+	style       BarStyle
+	bars        []*progressEntry
+	index       map[string]*progressEntry
+	lines       int
+	lastDraw    time.Time
+	stopped     bool
+	now         func() time.Time
+}
+
+type progressEntry struct {
+	name     string
+	progress int
+	total    int
+	logged   bool
+}
+
+// NewMultiProgress create a multi progress renderer writing to w
+func NewMultiProgress(w io.Writer) *MultiProgress {
+	return &MultiProgress{
+		w:           w,
+		terminal:    IsTerminal(w),
+		color:       ColorEnabled(w),
+		level:       outputColorLevel(w),
+		refresh:     DefaultRefreshInterval,
+		logInterval: DefaultLogInterval,
+		style:       DefaultBarStyle,
+		index:       make(map[string]*progressEntry),
+		now:         time.Now,
+	}
+}
+
+// SetTerminal override terminal detection of the writer
+func (p *MultiProgress) SetTerminal(terminal bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.terminal = terminal
+}
+
+// SetColor override color detection of the writer, colors of the style are dropped when disabled
+// and downgraded to the level of the writer otherwise
+func (p *MultiProgress) SetColor(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.color = enabled
+}
+
+// SetRefreshInterval set the minimum delay between two redraws on a terminal
+func (p *MultiProgress) SetRefreshInterval(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refresh = d
+}
+
+// SetLogInterval set the delay between two plain line logs when output is not a terminal
+func (p *MultiProgress) SetLogInterval(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.logInterval = d
+}
+
+// SetStyle set the style used to draw every bar
+func (p *MultiProgress) SetStyle(style BarStyle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.style = style
+}
+
+// Add register a bar with name and total, an existing bar with the same name is reset
+func (p *MultiProgress) Add(name string, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.index[name]; ok {
+		e.progress, e.total, e.logged = 0, total, false
+	} else {
+		e = &progressEntry{name: name, total: total}
+		p.bars = append(p.bars, e)
+		p.index[name] = e
+	}
+	p.render(true)
+}
+
+// Set set the progress of the named bar, unknown names are ignored
+func (p *MultiProgress) Set(name string, progress int) {
+	p.update(name, func(e *progressEntry) { e.progress = progress })
+}
+
+// Incr increase the progress of the named bar by n, unknown names are ignored
+func (p *MultiProgress) Incr(name string, n int) {
+	p.update(name, func(e *progressEntry) { e.progress += n })
+}
+
+// Stop render the final state of all bars, later updates are ignored
+func (p *MultiProgress) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	if p.terminal {
+		p.draw()
+	} else {
+		p.log(func(e *progressEntry) bool { return !e.logged })
+	}
+	p.stopped = true
+}
+
+func (p *MultiProgress) update(name string, fn func(e *progressEntry)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e, ok := p.index[name]
+	if !ok || p.stopped {
+		return
+	}
+	wasFinished := e.finished()
+	fn(e)
+	p.render(!wasFinished && e.finished())
+}
+
+// render must be called with p.mu held
+func (p *MultiProgress) render(force bool) {
+	if p.stopped {
+		return
+	}
+	now := p.now()
+	if p.terminal {
+		if force || now.Sub(p.lastDraw) >= p.refresh {
+			p.draw()
+			p.lastDraw = now
+		}
+		return
+	}
+
+	if now.Sub(p.lastDraw) >= p.logInterval {
+		p.log(func(e *progressEntry) bool { return !e.logged })
+		p.lastDraw = now
+		return
+	}
+	// completed bars are always logged once, even between two intervals
+	p.log(func(e *progressEntry) bool { return !e.logged && e.finished() })
+}
+
+// draw redraw all bars in place, moving the cursor back to the top of the previous block
+func (p *MultiProgress) draw() {
+	var b strings.Builder
+	if p.lines > 0 {
+		fmt.Fprintf(&b, ansiCursorUp, p.lines)
+	}
+	for _, e := range p.bars {
+		b.WriteString("\r")
+		b.WriteString(ansiClearLine)
+		b.WriteString(p.line(e))
+		b.WriteString("\n")
+	}
+	p.lines = len(p.bars)
+	_, _ = io.WriteString(p.w, b.String())
+}
+
+// log write the bars matched by filter as plain lines
+func (p *MultiProgress) log(filter func(e *progressEntry) bool) {
+	var b strings.Builder
+	for _, e := range p.bars {
+		if !filter(e) {
+			continue
+		}
+		b.WriteString(p.line(e))
+		b.WriteString("\n")
+		if e.finished() {
+			e.logged = true
+		}
+	}
+	if b.Len() > 0 {
+		_, _ = io.WriteString(p.w, b.String())
+	}
+}
+
+func (p *MultiProgress) line(e *progressEntry) string {
+	if !p.color {
+		return StripANSI(p.style.render(e.name, e.progress, e.total, nil, ColorNone))
+	}
+	return p.style.render(e.name, e.progress, e.total, nil, p.level)
+}
+
+func (e *progressEntry) finished() bool {
+	return e.total > 0 && e.progress >= e.total
+}
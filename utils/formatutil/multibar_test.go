@@ -0,0 +1,113 @@
+package formatutil
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMultiProgressPlainLogging(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewMultiProgress(&buf)
+	now := time.Unix(0, 0)
+	p.now = func() time.Time { return now }
+
+	p.Add("a", 10)
+	p.Add("b", 10)
+	buf.Reset()
+
+	p.Set("a", 5)
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output between log intervals, got %q", buf.String())
+	}
+
+	p.Set("b", 10)
+	if got := buf.String(); !strings.Contains(got, "b: [") || strings.Contains(got, "a: [") {
+		t.Fatalf("expected only completed bar to be logged, got %q", got)
+	}
+	buf.Reset()
+
+	now = now.Add(DefaultLogInterval)
+	p.Incr("a", 1)
+	got := buf.String()
+	if !strings.Contains(got, "(6/10)") {
+		t.Fatalf("expected periodic log of bar a, got %q", got)
+	}
+	if strings.Contains(got, "b: [") {
+		t.Fatalf("expected completed bar b to be logged only once, got %q", got)
+	}
+	if strings.Contains(got, "\x1b[") || strings.Contains(got, "\r") {
+		t.Fatalf("expected plain lines without control sequences, got %q", got)
+	}
+}
+
+func TestMultiProgressTerminalRedraw(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewMultiProgress(&buf)
+	p.SetTerminal(true)
+	p.SetRefreshInterval(0)
+
+	p.Add("a", 10)
+	p.Add("b", 10)
+	buf.Reset()
+
+	p.Set("a", 3)
+	got := buf.String()
+	if !strings.HasPrefix(got, "\x1b[2A") {
+		t.Fatalf("expected cursor to move up over 2 bars, got %q", got)
+	}
+	if strings.Count(got, "\n") != 2 {
+		t.Fatalf("expected 2 redrawn lines, got %q", got)
+	}
+	if !strings.Contains(got, "(3/10)") || !strings.Contains(got, "(0/10)") {
+		t.Fatalf("expected both bars in block, got %q", got)
+	}
+}
+
+func TestMultiProgressIgnoreUnknownAndStopped(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewMultiProgress(&buf)
+	p.SetLogInterval(0)
+	p.Add("a", 2)
+	p.Set("missing", 1)
+	p.Stop()
+	buf.Reset()
+
+	p.Set("a", 2)
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output after stop, got %q", buf.String())
+	}
+}
+
+func TestMultiProgressConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewMultiProgress(&buf)
+	p.SetLogInterval(time.Hour)
+
+	const workers = 8
+	for i := 0; i < workers; i++ {
+		p.Add(fmt.Sprintf("worker-%d", i), 100)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				p.Incr(name, 1)
+			}
+		}(fmt.Sprintf("worker-%d", i))
+	}
+	wg.Wait()
+	p.Stop()
+
+	for i := 0; i < workers; i++ {
+		line := strings.TrimPrefix(ProgressBar(fmt.Sprintf("worker-%d", i), 100, 100), "\r") + "\n"
+		if c := strings.Count(buf.String(), line); c != 1 {
+			t.Fatalf("expected worker-%d completion logged once, got %d in %q", i, c, buf.String())
+		}
+	}
+}
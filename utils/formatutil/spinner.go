@@ -0,0 +1,203 @@
+package formatutil
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultSpinnerInterval is the delay between two spinner frames
+const DefaultSpinnerInterval = 100 * time.Millisecond
+
+// frame sets of Spinner
+var (
+	SpinnerDots   = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	SpinnerLine   = []string{"-", "\\", "|", "/"}
+	SpinnerCircle = []string{"◐", "◓", "◑", "◒"}
+	SpinnerArrow  = []string{"←", "↖", "↑", "↗", "→", "↘", "↓", "↙"}
+	SpinnerBounce = []string{"[=   ]", "[ =  ]", "[  = ]", "[   =]", "[  = ]", "[ =  ]"}
+)
+
+// Spinner render an indeterminate progress indicator for work of unknown size.
+// On a terminal the frames are animated by a background goroutine,
+// otherwise only the message and the final status line are written.
+type Spinner struct {
+	mu            sync.Mutex
+	w             io.Writer
+	frames        []string
+	interval      time.Duration
+	message       string
+	successSymbol string
+	failSymbol    string
+	terminal      bool
+	frame         int
+	running       bool
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+// NewSpinner create a spinner writing to w with SpinnerDots frames
+func NewSpinner(w io.Writer, message string) *Spinner {
+	return &Spinner{
+		w:             w,
+		frames:        SpinnerDots,
+		interval:      DefaultSpinnerInterval,
+		message:       message,
+		successSymbol: "✔",
+		failSymbol:    "✖",
+		terminal:      IsTerminal(w),
+	}
+}
+
+// SetFrames set the frames of the animation, exp: SpinnerLine
+func (s *Spinner) SetFrames(frames []string) {
+	if len(frames) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frames = frames
+	s.frame = 0
+}
+
+// SetInterval set the delay between two frames, it takes effect on next Start
+func (s *Spinner) SetInterval(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interval = d
+}
+
+// SetSymbols set the symbols of the Success and Fail status lines
+func (s *Spinner) SetSymbols(success, fail string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.successSymbol, s.failSymbol = success, fail
+}
+
+// SetTerminal override terminal detection of the writer
+func (s *Spinner) SetTerminal(terminal bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.terminal = terminal
+}
+
+// SetMessage set the message shown next to the spinner
+func (s *Spinner) SetMessage(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = message
+}
+
+// Start start the animation in background, the spinner fails with ctx.Err() when ctx is done
+func (s *Spinner) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return
+	}
+	s.running = true
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	if s.terminal {
+		s.draw()
+	} else {
+		_, _ = io.WriteString(s.w, s.message+"\n")
+	}
+	go s.run(ctx, s.interval, s.stop, s.done)
+}
+
+// Stop stop the animation and clear the spinner line
+func (s *Spinner) Stop() {
+	s.finish(func() {
+		if s.terminal {
+			_, _ = io.WriteString(s.w, "\r"+ansiClearLine)
+		}
+	})
+}
+
+// Success stop the animation and print a success status line, the current message is used if msg is empty
+func (s *Spinner) Success(msg string) {
+	s.finish(func() { s.status(s.successSymbol, msg) })
+}
+
+// Fail stop the animation and print a failure status line, the current message is used if msg is empty
+func (s *Spinner) Fail(msg string) {
+	s.finish(func() { s.status(s.failSymbol, msg) })
+}
+
+// Wait block until the background goroutine exits, exp: after the context is canceled
+func (s *Spinner) Wait() {
+	s.mu.Lock()
+	done := s.done
+	s.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+}
+
+func (s *Spinner) run(ctx context.Context, interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.running {
+				s.running = false
+				close(s.stop)
+				s.status(s.failSymbol, s.message+": "+ctx.Err().Error())
+			}
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.terminal {
+				s.frame = (s.frame + 1) % len(s.frames)
+				s.draw()
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// finish stop the background goroutine then call final with s.mu held
+func (s *Spinner) finish(final func()) {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	close(s.stop)
+	done := s.done
+	s.mu.Unlock()
+
+	<-done
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	final()
+}
+
+// draw must be called with s.mu held
+func (s *Spinner) draw() {
+	_, _ = io.WriteString(s.w, "\r"+ansiClearLine+s.frames[s.frame]+" "+s.message)
+}
+
+// status must be called with s.mu held
+func (s *Spinner) status(symbol, msg string) {
+	if msg == "" {
+		msg = s.message
+	}
+	line := symbol + " " + msg + "\n"
+	if s.terminal {
+		line = "\r" + ansiClearLine + line
+	}
+	_, _ = io.WriteString(s.w, line)
+}
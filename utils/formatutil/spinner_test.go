@@ -0,0 +1,95 @@
+package formatutil
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for the spinner goroutine and the test to share
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSpinnerPlainOutput(t *testing.T) {
+	var out syncBuffer
+	s := NewSpinner(&out, "loading")
+	s.Start(context.Background())
+	s.Success("loaded")
+	s.Fail("ignored after finish")
+
+	if got, want := out.String(), "loading\n✔ loaded\n"; got != want {
+		t.Fatalf("unexpected output %q, want %q", got, want)
+	}
+}
+
+func TestSpinnerTerminalAnimation(t *testing.T) {
+	var out syncBuffer
+	s := NewSpinner(&out, "working")
+	s.SetTerminal(true)
+	s.SetFrames(SpinnerLine)
+	s.SetInterval(time.Millisecond)
+	s.Start(context.Background())
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), "\\ working") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	s.SetSymbols("OK", "FAIL")
+	s.Fail("")
+
+	got := out.String()
+	if !strings.HasPrefix(got, "\r\x1b[2K- working") {
+		t.Fatalf("expected first frame drawn on start, got %q", got)
+	}
+	if !strings.Contains(got, "\\ working") {
+		t.Fatalf("expected animated frames, got %q", got)
+	}
+	if !strings.HasSuffix(got, "\r\x1b[2KFAIL working\n") {
+		t.Fatalf("expected failure line with current message, got %q", got)
+	}
+}
+
+func TestSpinnerContextCancel(t *testing.T) {
+	var out syncBuffer
+	s := NewSpinner(&out, "waiting")
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	cancel()
+	s.Wait()
+	s.Stop()
+
+	if got, want := out.String(), "waiting\n✖ waiting: context canceled\n"; got != want {
+		t.Fatalf("unexpected output %q, want %q", got, want)
+	}
+}
+
+func TestBarSpinnerPlaceholder(t *testing.T) {
+	clock := time.Unix(0, 0)
+	b := newTestBar("scan", 0, &clock)
+	b.SetStyle(BarStyle{Template: UnknownTotalTemplate})
+	b.Add(3)
+	if got := b.String(); !strings.HasPrefix(got, "scan: ⠋ 3 ") {
+		t.Fatalf("unexpected render %q", got)
+	}
+	clock = clock.Add(DefaultSpinnerInterval)
+	if got := b.String(); !strings.HasPrefix(got, "scan: ⠙ 3 ") {
+		t.Fatalf("expected next frame, got %q", got)
+	}
+}
@@ -0,0 +1,175 @@
+package formatutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultBarWidth is the number of cells of a bar when BarStyle.Width is not set
+	DefaultBarWidth = 50
+	// DefaultBarTemplate is the layout of ProgressBar
+	DefaultBarTemplate = "{name}: [{bar}] {percent} ({current}/{total})"
+	// StatsBarTemplate is the layout of Bar, it adds rate, ETA and elapsed time to DefaultBarTemplate
+	StatsBarTemplate = DefaultBarTemplate + " {rate} ETA {eta} elapsed {elapsed}"
+	// UnknownTotalTemplate is the layout for work whose total is unknown
+	UnknownTotalTemplate = "{name}: {spinner} {current} {rate} elapsed {elapsed}"
+)
+
+// BarStyle describe how a progress bar is drawn.
+// Glyphs must be one cell wide, wide glyphs like CJK characters make the bar longer than Width.
+// Template placeholders: {name} {bar} {percent} {current} {total},
+// Bar also provides {rate} {eta} {elapsed} {spinner} and any value set by Bar.SetValue,
+// unknown placeholders are kept as is.
+type BarStyle struct {
+	// Width is the number of cells of the bar, DefaultBarWidth if not positive
+	Width int
+	// Fill is the glyph of a completed cell, "█" or "=" in ASCII mode if empty
+	Fill string
+	// Empty is the glyph of a remaining cell, " " if empty
+	Empty string
+	// Head is the glyph drawn right after the completed cells, exp: "=====>    "
+	Head string
+	// Partials are glyphs of a partially completed cell, from least to most complete
+	Partials []string
+	// ASCII replace every non-ASCII glyph with an ASCII one, for terminals without Unicode
+	ASCII bool
+	// Template is the layout of the rendered line, DefaultBarTemplate if empty
+	Template string
+	// FillColor is the style of the completed cells, exp: NewStyle().Fg(Green)
+	FillColor Style
+	// EmptyColor is the style of the remaining cells
+	EmptyColor Style
+}
+
+var (
+	// DefaultBarStyle is the style of ProgressBar
+	DefaultBarStyle = BarStyle{Width: DefaultBarWidth, Fill: "█", Empty: " ", Template: DefaultBarTemplate}
+	// SmoothBarStyle draw partially completed cells with eighth blocks
+	SmoothBarStyle = BarStyle{Width: DefaultBarWidth, Fill: "█", Empty: " ", Partials: []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉"}, Template: DefaultBarTemplate}
+	// ASCIIBarStyle only use ASCII glyphs
+	// exp: "task: [=========>          ]  50% (5/10)"
+	ASCIIBarStyle = BarStyle{Width: DefaultBarWidth, Fill: "=", Empty: " ", Head: ">", ASCII: true, Template: DefaultBarTemplate}
+)
+
+// Render render the progress line of name with the style, colors are rendered assuming true color support
+func (s BarStyle) Render(name string, progress, total int) string {
+	return s.RenderWith(name, progress, total, nil)
+}
+
+// RenderWith render the progress line of name with the style, values fill custom placeholders
+// and take precedence over the builtin ones, colors are rendered assuming true color support
+// exp: RenderWith("task", 1, 2, map[string]string{"status": "ok"}) with template "{name} {status}"
+func (s BarStyle) RenderWith(name string, progress, total int, values map[string]string) string {
+	return s.render(name, progress, total, values, ColorTrue)
+}
+
+// render render the progress line with colors downgraded to level
+func (s BarStyle) render(name string, progress, total int, values map[string]string, level ColorLevel) string {
+	var percentage float64
+	if total != 0 {
+		percentage = float64(progress) / float64(total) * 100
+	}
+	tpl := s.Template
+	if tpl == "" {
+		tpl = DefaultBarTemplate
+	}
+	return expandPlaceholders(tpl, func(key string) (string, bool) {
+		if v, ok := values[key]; ok {
+			return v, true
+		}
+		switch key {
+		case "name":
+			return name, true
+		case "bar":
+			return s.bar(percentage/100, level), true
+		case "percent":
+			return fmt.Sprintf("%3.0f%%", percentage), true
+		case "current":
+			return strconv.Itoa(progress), true
+		case "total":
+			return strconv.Itoa(total), true
+		}
+		return "", false
+	})
+}
+
+// bar draw the cells for ratio in [0, 1] with colors at level
+func (s BarStyle) bar(ratio float64, level ColorLevel) string {
+	if s.Fill == "" {
+		s.Fill = DefaultBarStyle.Fill
+	}
+	if s.Empty == "" {
+		s.Empty = DefaultBarStyle.Empty
+	}
+	if s.ASCII {
+		s = s.asciiOnly()
+	}
+	width := s.Width
+	if width <= 0 {
+		width = DefaultBarWidth
+	}
+	ratio = max(0, min(ratio, 1))
+
+	cells := ratio * float64(width)
+	full := min(int(cells), width)
+	filled := strings.Repeat(s.Fill, full)
+	drawn := full
+	if drawn < width {
+		if len(s.Partials) > 0 {
+			if i := int((cells - float64(full)) * float64(len(s.Partials)+1)); i > 0 {
+				filled += s.Partials[min(i, len(s.Partials))-1]
+				drawn++
+			}
+		} else if s.Head != "" && ratio > 0 {
+			filled += s.Head
+			drawn++
+		}
+	}
+	return s.FillColor.render(filled, level) + s.EmptyColor.render(strings.Repeat(s.Empty, width-drawn), level)
+}
+
+// asciiOnly return a copy of the style with non-ASCII glyphs replaced
+func (s BarStyle) asciiOnly() BarStyle {
+	s.Fill = asciiGlyph(s.Fill, "=")
+	s.Empty = asciiGlyph(s.Empty, " ")
+	s.Head = asciiGlyph(s.Head, ">")
+	s.Partials = nil
+	return s
+}
+
+func asciiGlyph(glyph, fallback string) string {
+	for i := 0; i < len(glyph); i++ {
+		if glyph[i] >= utf8.RuneSelf {
+			return fallback
+		}
+	}
+	return glyph
+}
+
+// expandPlaceholders replace every {key} in tpl with lookup(key), unknown keys are kept as is
+func expandPlaceholders(tpl string, lookup func(key string) (string, bool)) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		b.WriteString(tpl[:start])
+		if v, ok := lookup(tpl[start+1 : end]); ok {
+			b.WriteString(v)
+		} else {
+			b.WriteString(tpl[start : end+1])
+		}
+		tpl = tpl[end+1:]
+	}
+	b.WriteString(tpl)
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package formatutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBarStyleRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    BarStyle
+		progress int
+		total    int
+		want     string
+	}{
+		{
+			name:     "defaultMatchesProgressBar",
+			style:    DefaultBarStyle,
+			progress: 25,
+			total:    100,
+			want:     strings.TrimPrefix(ProgressBar("task", 25, 100), "\r"),
+		},
+		{
+			name:     "customWidthAndGlyphs",
+			style:    BarStyle{Width: 10, Fill: "#", Empty: ".", Template: "[{bar}] {percent}"},
+			progress: 3,
+			total:    10,
+			want:     "[###.......]  30%",
+		},
+		{
+			name:     "head",
+			style:    BarStyle{Width: 10, Fill: "=", Empty: " ", Head: ">", Template: "[{bar}]"},
+			progress: 5,
+			total:    10,
+			want:     "[=====>    ]",
+		},
+		{
+			name:     "headNotDrawnWhenComplete",
+			style:    BarStyle{Width: 4, Fill: "=", Empty: " ", Head: ">", Template: "[{bar}]"},
+			progress: 4,
+			total:    4,
+			want:     "[====]",
+		},
+		{
+			name:     "partials",
+			style:    BarStyle{Width: 4, Fill: "█", Empty: " ", Partials: []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉"}, Template: "{bar}"},
+			progress: 3,
+			total:    8,
+			want:     "█▌  ",
+		},
+		{
+			name:     "asciiOnly",
+			style:    BarStyle{Width: 4, Fill: "█", Empty: "░", Partials: []string{"▌"}, ASCII: true, Template: "{bar}"},
+			progress: 2,
+			total:    4,
+			want:     "==  ",
+		},
+		{
+			name:     "defaultGlyphs",
+			style:    BarStyle{Width: 4, Template: "[{bar}]"},
+			progress: 2,
+			total:    4,
+			want:     "[██  ]",
+		},
+		{
+			name:     "asciiDefaultGlyphs",
+			style:    BarStyle{Width: 4, ASCII: true, Template: "[{bar}]"},
+			progress: 2,
+			total:    4,
+			want:     "[==  ]",
+		},
+		{
+			name:     "placeholders",
+			style:    BarStyle{Width: 2, Fill: "#", Empty: "-", Template: "{name} {current}/{total} {unknown}"},
+			progress: 1,
+			total:    2,
+			want:     "task 1/2 {unknown}",
+		},
+		{
+			name:     "negativeProgress",
+			style:    BarStyle{Width: 3, Fill: "#", Empty: "-", Template: "{bar}"},
+			progress: -1,
+			total:    2,
+			want:     "---",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.Render("task", tt.progress, tt.total); got != tt.want {
+				t.Fatalf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBarStyleRenderWithValues(t *testing.T) {
+	style := BarStyle{Width: 2, Fill: "#", Empty: "-", Template: "{name} [{bar}] {status}"}
+	got := style.RenderWith("task", 1, 2, map[string]string{"status": "copying"})
+	if got != "task [#-] copying" {
+		t.Fatalf("unexpected render %q", got)
+	}
+}
+
+func TestBarSetStyleAndValue(t *testing.T) {
+	b := NewBar("task", 10)
+	b.SetStyle(BarStyle{Width: 10, Fill: "=", Empty: " ", Template: "{name} {bar} {file}"})
+	b.SetValue("file", "a.txt")
+	b.Add(5)
+	if got := b.String(); got != "task =====      a.txt" {
+		t.Fatalf("unexpected render %q", got)
+	}
+
+	style := ASCIIBarStyle
+	style.Template = ""
+	b.SetStyle(style)
+	if got := b.String(); !strings.Contains(got, "ETA") {
+		t.Fatalf("expected stats template when style has none, got %q", got)
+	}
+}
@@ -0,0 +1,289 @@
+package formatutil
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ekreke/gobase/utils/stringutil"
+)
+
+// Align is the horizontal alignment of a table column
+type Align = stringutil.Align
+
+// alignments of a table column
+const (
+	AlignLeft   = stringutil.AlignLeft
+	AlignRight  = stringutil.AlignRight
+	AlignCenter = stringutil.AlignCenter
+)
+
+// TableBorder is the border style of a Table
+type TableBorder int
+
+// border styles of a Table
+const (
+	// BorderNone separate columns with two spaces
+	BorderNone TableBorder = iota
+	// BorderASCII draw borders with "+", "-" and "|"
+	BorderASCII
+	// BorderUnicode draw borders with box drawing characters
+	BorderUnicode
+	// BorderMarkdown render a GitHub flavored markdown table
+	BorderMarkdown
+)
+
+// DefaultEllipsis is appended to cells truncated by Table.SetMaxWidth
+const DefaultEllipsis = "…"
+
+// borderGlyphs are the glyphs of a box border, indexed by row position then column position
+type borderGlyphs struct {
+	horizontal, vertical string
+	top, mid, bottom     [3]string // left, cross, right
+}
+
+var (
+	asciiBorder   = borderGlyphs{horizontal: "-", vertical: "|", top: [3]string{"+", "+", "+"}, mid: [3]string{"+", "+", "+"}, bottom: [3]string{"+", "+", "+"}}
+	unicodeBorder = borderGlyphs{horizontal: "─", vertical: "│", top: [3]string{"┌", "┬", "┐"}, mid: [3]string{"├", "┼", "┤"}, bottom: [3]string{"└", "┴", "┘"}}
+)
+
+// Table render rows as aligned text columns, widths are computed by display width so CJK and emoji are aligned
+// exp:
+//
+//	t := NewTable("name", "age")
+//	t.SetBorder(BorderASCII)
+//	t.AddRow("bob", 3)
+//	fmt.Print(t)
+//	// +------+-----+
+//	// | name | age |
+//	// +------+-----+
+//	// | bob  | 3   |
+//	// +------+-----+
+type Table struct {
+	headers      []string
+	rows         [][]string
+	aligns       map[int]Align
+	maxWidths    map[int]int
+	border       TableBorder
+	rowSeparator bool
+	ellipsis     string
+}
+
+// NewTable create a table with headers, no header line is drawn if headers is empty
+func NewTable(headers ...string) *Table {
+	return &Table{
+		headers:   headers,
+		aligns:    make(map[int]Align),
+		maxWidths: make(map[int]int),
+		ellipsis:  DefaultEllipsis,
+	}
+}
+
+// SetBorder set the border style, BorderNone by default
+func (t *Table) SetBorder(border TableBorder) {
+	t.border = border
+}
+
+// SetAlign set the alignment of the columns in order
+func (t *Table) SetAlign(aligns ...Align) {
+	for i, a := range aligns {
+		t.aligns[i] = a
+	}
+}
+
+// SetColumnAlign set the alignment of column col
+func (t *Table) SetColumnAlign(col int, align Align) {
+	t.aligns[col] = align
+}
+
+// SetMaxWidth truncate the cells of column col wider than width, not positive means unlimited
+func (t *Table) SetMaxWidth(col, width int) {
+	t.maxWidths[col] = width
+}
+
+// SetEllipsis set the suffix of truncated cells, DefaultEllipsis by default
+func (t *Table) SetEllipsis(ellipsis string) {
+	t.ellipsis = ellipsis
+}
+
+// SetRowSeparator draw a separator line between rows
+func (t *Table) SetRowSeparator(enabled bool) {
+	t.rowSeparator = enabled
+}
+
+// AddRow append a row, cells are formatted with fmt.Sprint
+func (t *Table) AddRow(cells ...interface{}) {
+	row := make([]string, len(cells))
+	for i, c := range cells {
+		row[i] = fmt.Sprint(c)
+	}
+	t.rows = append(t.rows, row)
+}
+
+// String return the rendered table
+func (t *Table) String() string {
+	var b strings.Builder
+	_ = t.Render(&b)
+	return b.String()
+}
+
+// Render write the table to w
+func (t *Table) Render(w io.Writer) error {
+	headers, rows := t.cells()
+	widths := t.widths(headers, rows)
+	if len(widths) == 0 {
+		return nil
+	}
+
+	var lines []string
+	switch t.border {
+	case BorderASCII, BorderUnicode:
+		g := asciiBorder
+		if t.border == BorderUnicode {
+			g = unicodeBorder
+		}
+		lines = append(lines, g.line(widths, g.top))
+		if headers != nil {
+			lines = append(lines, t.boxRow(g, headers, widths), g.line(widths, g.mid))
+		}
+		for i, row := range rows {
+			if i > 0 && t.rowSeparator {
+				lines = append(lines, g.line(widths, g.mid))
+			}
+			lines = append(lines, t.boxRow(g, row, widths))
+		}
+		lines = append(lines, g.line(widths, g.bottom))
+	case BorderMarkdown:
+		if headers == nil {
+			// markdown tables always need a header row
+			headers = make([]string, len(widths))
+		}
+		lines = append(lines, t.boxRow(asciiBorder, headers, widths), t.markdownSeparator(widths))
+		for _, row := range rows {
+			lines = append(lines, t.boxRow(asciiBorder, row, widths))
+		}
+	default:
+		if headers != nil {
+			lines = append(lines, t.plainRow(headers, widths))
+		}
+		for i, row := range rows {
+			if i > 0 && t.rowSeparator {
+				lines = append(lines, "")
+			}
+			lines = append(lines, t.plainRow(row, widths))
+		}
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// cells return headers and rows normalized to the same number of columns and truncated
+func (t *Table) cells() ([]string, [][]string) {
+	cols := len(t.headers)
+	for _, row := range t.rows {
+		cols = max(cols, len(row))
+	}
+	normalize := func(in []string) []string {
+		out := make([]string, cols)
+		for i, c := range in {
+			c = strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(c)
+			if t.border == BorderMarkdown {
+				c = strings.ReplaceAll(c, "|", `\|`)
+			}
+			out[i] = truncateWidth(c, t.maxWidths[i], t.ellipsis)
+		}
+		return out
+	}
+
+	var headers []string
+	if len(t.headers) > 0 {
+		headers = normalize(t.headers)
+	}
+	rows := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = normalize(row)
+	}
+	return headers, rows
+}
+
+func (t *Table) widths(headers []string, rows [][]string) []int {
+	cols := len(headers)
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+	widths := make([]int, cols)
+	if t.border == BorderMarkdown {
+		// room for the ":---:" alignment row
+		for i := range widths {
+			widths[i] = 3
+		}
+	}
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, c := range row {
+			widths[i] = max(widths[i], stringutil.DisplayWidth(StripANSI(c)))
+		}
+	}
+	return widths
+}
+
+func (t *Table) boxRow(g borderGlyphs, row []string, widths []int) string {
+	var b strings.Builder
+	b.WriteString(g.vertical)
+	for i, c := range row {
+		b.WriteString(" " + padWidth(c, widths[i], t.aligns[i]) + " " + g.vertical)
+	}
+	return b.String()
+}
+
+func (t *Table) plainRow(row []string, widths []int) string {
+	cells := make([]string, len(row))
+	for i, c := range row {
+		cells[i] = padWidth(c, widths[i], t.aligns[i])
+	}
+	return strings.TrimRight(strings.Join(cells, "  "), " ")
+}
+
+func (t *Table) markdownSeparator(widths []int) string {
+	var b strings.Builder
+	b.WriteString("|")
+	for i, w := range widths {
+		switch t.aligns[i] {
+		case AlignRight:
+			b.WriteString(" " + strings.Repeat("-", w-1) + ": |")
+		case AlignCenter:
+			b.WriteString(" :" + strings.Repeat("-", w-2) + ": |")
+		default:
+			b.WriteString(" :" + strings.Repeat("-", w-1) + " |")
+		}
+	}
+	return b.String()
+}
+
+func (g borderGlyphs) line(widths []int, glyphs [3]string) string {
+	var b strings.Builder
+	b.WriteString(glyphs[0])
+	for i, w := range widths {
+		if i > 0 {
+			b.WriteString(glyphs[1])
+		}
+		b.WriteString(strings.Repeat(g.horizontal, w+2))
+	}
+	b.WriteString(glyphs[2])
+	return b.String()
+}
+
+// padWidth pad s with spaces to width display cells, ANSI sequences take no cell
+func padWidth(s string, width int, align Align) string {
+	invisible := stringutil.DisplayWidth(s) - stringutil.DisplayWidth(StripANSI(s))
+	return stringutil.PadWidth(s, width+invisible, align, ' ')
+}
+
+// truncateWidth cut s to at most width display cells ending with ellipsis, not positive width means unlimited,
+// ANSI sequences of truncated text are dropped
+func truncateWidth(s string, width int, ellipsis string) string {
+	if width <= 0 || stringutil.DisplayWidth(StripANSI(s)) <= width {
+		return s
+	}
+	return stringutil.TruncateWidth(StripANSI(s), width, ellipsis)
+}
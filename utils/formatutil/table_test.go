@@ -0,0 +1,106 @@
+package formatutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableBorders(t *testing.T) {
+	tests := []struct {
+		name   string
+		border TableBorder
+		want   string
+	}{
+		{
+			name:   "none",
+			border: BorderNone,
+			want: "name   age\n" +
+				"bob      3\n" +
+				"alice   42\n",
+		},
+		{
+			name:   "ascii",
+			border: BorderASCII,
+			want: "+-------+-----+\n" +
+				"| name  | age |\n" +
+				"+-------+-----+\n" +
+				"| bob   |   3 |\n" +
+				"| alice |  42 |\n" +
+				"+-------+-----+\n",
+		},
+		{
+			name:   "unicode",
+			border: BorderUnicode,
+			want: "┌───────┬─────┐\n" +
+				"│ name  │ age │\n" +
+				"├───────┼─────┤\n" +
+				"│ bob   │   3 │\n" +
+				"│ alice │  42 │\n" +
+				"└───────┴─────┘\n",
+		},
+		{
+			name:   "markdown",
+			border: BorderMarkdown,
+			want: "| name  | age |\n" +
+				"| :---- | --: |\n" +
+				"| bob   |   3 |\n" +
+				"| alice |  42 |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable("name", "age")
+			table.SetBorder(tt.border)
+			table.SetAlign(AlignLeft, AlignRight)
+			table.AddRow("bob", 3)
+			table.AddRow("alice", 42)
+			if got := table.String(); got != tt.want {
+				t.Fatalf("unexpected table:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableDisplayWidth(t *testing.T) {
+	table := NewTable("名字", "x")
+	table.SetBorder(BorderASCII)
+	table.SetColumnAlign(0, AlignCenter)
+	table.AddRow("a", "😀")
+	want := "+------+----+\n" +
+		"| 名字 | x  |\n" +
+		"+------+----+\n" +
+		"|  a   | 😀 |\n" +
+		"+------+----+\n"
+	if got := table.String(); got != want {
+		t.Fatalf("unexpected table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTableTruncateAndSeparator(t *testing.T) {
+	table := NewTable()
+	table.SetBorder(BorderASCII)
+	table.SetRowSeparator(true)
+	table.SetMaxWidth(0, 5)
+	table.AddRow("hello world", "short")
+	table.AddRow("你好世界")
+	want := "+-------+-------+\n" +
+		"| hell… | short |\n" +
+		"+-------+-------+\n" +
+		"| 你好… |       |\n" +
+		"+-------+-------+\n"
+	if got := table.String(); got != want {
+		t.Fatalf("unexpected table:\n%s\nwant:\n%s", got, want)
+	}
+
+	table.SetEllipsis("...")
+	if got := table.String(); !strings.Contains(got, "| he... |") {
+		t.Fatalf("expected custom ellipsis, got:\n%s", got)
+	}
+}
+
+func TestTableEmpty(t *testing.T) {
+	if got := NewTable().String(); got != "" {
+		t.Fatalf("expected empty output, got %q", got)
+	}
+}
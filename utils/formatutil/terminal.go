@@ -0,0 +1,24 @@
+package formatutil
+
+import (
+	"io"
+	"os"
+)
+
+const (
+	ansiClearLine = "\x1b[2K"
+	ansiCursorUp  = "\x1b[%dA"
+)
+
+// IsTerminal check if writer is an interactive terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
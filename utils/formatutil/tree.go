@@ -0,0 +1,216 @@
+package formatutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// TreeNode is a node of a tree rendered by RenderTree
+type TreeNode interface {
+	Label() string
+	Children() []TreeNode
+}
+
+// Tree is a simple TreeNode implementation
+// exp:
+//
+//	root := &Tree{Name: "app", Nodes: []*Tree{{Name: "db"}, {Name: "cache"}}}
+//	fmt.Print(RenderTree(root, TreeOptions{}))
+//	// app
+//	// ├── db
+//	// └── cache
+type Tree struct {
+	Name  string
+	Nodes []*Tree
+}
+
+// Label return the name of the node
+func (t *Tree) Label() string {
+	return t.Name
+}
+
+// Children return the child nodes
+func (t *Tree) Children() []TreeNode {
+	nodes := make([]TreeNode, len(t.Nodes))
+	for i, n := range t.Nodes {
+		nodes[i] = n
+	}
+	return nodes
+}
+
+// Add append a child named name and return it
+func (t *Tree) Add(name string) *Tree {
+	child := &Tree{Name: name}
+	t.Nodes = append(t.Nodes, child)
+	return child
+}
+
+// TreeOptions control how a tree is rendered
+type TreeOptions struct {
+	// MaxDepth limit the rendered depth below the root, not positive means unlimited
+	MaxDepth int
+	// SortKeys sort children by label
+	SortKeys bool
+	// ASCII draw branches with "|--" and "`--" for terminals without Unicode
+	ASCII bool
+}
+
+type treeGlyphs struct {
+	branch, last, pipe, space string
+}
+
+var (
+	unicodeTreeGlyphs = treeGlyphs{branch: "├── ", last: "└── ", pipe: "│   ", space: "    "}
+	asciiTreeGlyphs   = treeGlyphs{branch: "|-- ", last: "`-- ", pipe: "|   ", space: "    "}
+)
+
+// RenderTree render root and its descendants, one node per line
+func RenderTree(root TreeNode, opts TreeOptions) string {
+	g := unicodeTreeGlyphs
+	if opts.ASCII {
+		g = asciiTreeGlyphs
+	}
+	var b strings.Builder
+	b.WriteString(root.Label())
+	b.WriteString("\n")
+	renderTreeChildren(&b, root, "", 1, opts, g)
+	return b.String()
+}
+
+// RenderTreeValue render a nested value as a tree named name: map keys and slice indexes become branches,
+// other values are printed next to their key, exp: the output of maputil.StructToMap.
+// Map keys are always sorted and slice elements keep their order, a map, slice or pointer
+// containing itself is printed as "name: <cycle>".
+//
+//	RenderTreeValue("config", map[string]interface{}{"port": 8080, "hosts": []interface{}{"a", "b"}}, TreeOptions{})
+//	// config
+//	// ├── hosts
+//	// │   ├── [0]: a
+//	// │   └── [1]: b
+//	// └── port: 8080
+func RenderTreeValue(name string, value interface{}, opts TreeOptions) string {
+	b := &valueTreeBuilder{maxDepth: opts.MaxDepth, visiting: make(map[treeVisit]bool)}
+	return RenderTree(b.node(name, reflect.ValueOf(value), 0), opts)
+}
+
+func renderTreeChildren(b *strings.Builder, node TreeNode, prefix string, depth int, opts TreeOptions, g treeGlyphs) {
+	if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+		return
+	}
+	children := node.Children()
+	// value trees are already sorted by key and slice elements must keep their order
+	if _, ok := node.(*valueTree); opts.SortKeys && !ok {
+		children = append([]TreeNode(nil), children...)
+		sort.SliceStable(children, func(i, j int) bool { return children[i].Label() < children[j].Label() })
+	}
+	for i, child := range children {
+		connector, next := g.branch, g.pipe
+		if i == len(children)-1 {
+			connector, next = g.last, g.space
+		}
+		b.WriteString(prefix + connector + child.Label() + "\n")
+		renderTreeChildren(b, child, prefix+next, depth+1, opts, g)
+	}
+}
+
+// valueTree is the TreeNode of a nested value
+type valueTree struct {
+	label    string
+	children []TreeNode
+}
+
+func (n *valueTree) Label() string        { return n.label }
+func (n *valueTree) Children() []TreeNode { return n.children }
+
+// treeVisit identify a map, slice or pointer on the current path, slices also need their length
+type treeVisit struct {
+	ptr uintptr
+	len int
+	t   reflect.Type
+}
+
+// valueTreeBuilder build value trees, it stops at maxDepth and tracks the visited containers to detect cycles
+type valueTreeBuilder struct {
+	maxDepth int
+	visiting map[treeVisit]bool
+}
+
+// node build the node of v labeled with name at depth below the root,
+// slices are labeled by index and keep their order
+func (b *valueTreeBuilder) node(name string, v reflect.Value, depth int) *valueTree {
+	var entered []treeVisit
+	defer func() {
+		for _, k := range entered {
+			delete(b.visiting, k)
+		}
+	}()
+	// enter mark v as being on the current path, it returns false if it already is
+	enter := func(v reflect.Value) bool {
+		k := treeVisit{ptr: v.Pointer(), t: v.Type()}
+		if v.Kind() == reflect.Slice {
+			k.len = v.Len()
+		}
+		if b.visiting[k] {
+			return false
+		}
+		b.visiting[k] = true
+		entered = append(entered, k)
+		return true
+	}
+	cycle := &valueTree{label: name + ": <cycle>"}
+
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) && !v.IsNil() {
+		if v.Kind() == reflect.Pointer && !enter(v) {
+			return cycle
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return &valueTree{label: name + ": <nil>"}
+	}
+	// children below MaxDepth are not rendered, so they are not built
+	leaf := b.maxDepth > 0 && depth >= b.maxDepth
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Len() == 0 {
+			return &valueTree{label: name + ": {}"}
+		}
+		if leaf {
+			return &valueTree{label: name}
+		}
+		if !enter(v) {
+			return cycle
+		}
+		keys := v.MapKeys()
+		// map iteration order is random, keep a stable order even without TreeOptions.SortKeys
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		node := &valueTree{label: name}
+		for _, k := range keys {
+			node.children = append(node.children, b.node(fmt.Sprint(k), v.MapIndex(k), depth+1))
+		}
+		return node
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			// print []byte as a value rather than one branch per byte
+			break
+		}
+		if v.Len() == 0 {
+			return &valueTree{label: name + ": []"}
+		}
+		if leaf {
+			return &valueTree{label: name}
+		}
+		if v.Kind() == reflect.Slice && !enter(v) {
+			return cycle
+		}
+		node := &valueTree{label: name}
+		for i := 0; i < v.Len(); i++ {
+			node.children = append(node.children, b.node(fmt.Sprintf("[%d]", i), v.Index(i), depth+1))
+		}
+		return node
+	}
+	return &valueTree{label: fmt.Sprintf("%s: %v", name, v.Interface())}
+}
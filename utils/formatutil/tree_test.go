@@ -0,0 +1,132 @@
+package formatutil
+
+import "testing"
+
+func TestRenderTree(t *testing.T) {
+	root := &Tree{Name: "app"}
+	db := root.Add("db")
+	db.Add("postgres")
+	db.Add("redis")
+	root.Add("cache")
+
+	tests := []struct {
+		name string
+		opts TreeOptions
+		want string
+	}{
+		{
+			name: "unicode",
+			want: "app\n" +
+				"├── db\n" +
+				"│   ├── postgres\n" +
+				"│   └── redis\n" +
+				"└── cache\n",
+		},
+		{
+			name: "asciiSorted",
+			opts: TreeOptions{ASCII: true, SortKeys: true},
+			want: "app\n" +
+				"|-- cache\n" +
+				"`-- db\n" +
+				"    |-- postgres\n" +
+				"    `-- redis\n",
+		},
+		{
+			name: "maxDepth",
+			opts: TreeOptions{MaxDepth: 1},
+			want: "app\n" +
+				"├── db\n" +
+				"└── cache\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderTree(root, tt.opts); got != tt.want {
+				t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTreeValue(t *testing.T) {
+	value := map[string]interface{}{
+		"port":  8080,
+		"hosts": []interface{}{"b", "a", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+		"db": map[string]interface{}{
+			"user":  "root",
+			"empty": map[string]interface{}{},
+			"pass":  nil,
+		},
+	}
+	want := "config\n" +
+		"├── db\n" +
+		"│   ├── empty: {}\n" +
+		"│   ├── pass: <nil>\n" +
+		"│   └── user: root\n" +
+		"├── hosts\n" +
+		"│   ├── [0]: b\n" +
+		"│   ├── [1]: a\n" +
+		"│   ├── [2]: c\n" +
+		"│   ├── [3]: d\n" +
+		"│   ├── [4]: e\n" +
+		"│   ├── [5]: f\n" +
+		"│   ├── [6]: g\n" +
+		"│   ├── [7]: h\n" +
+		"│   ├── [8]: i\n" +
+		"│   ├── [9]: j\n" +
+		"│   └── [10]: k\n" +
+		"└── port: 8080\n"
+	if got := RenderTreeValue("config", value, TreeOptions{SortKeys: true}); got != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := RenderTreeValue("raw", []byte("hi"), TreeOptions{}); got != "raw: [104 105]\n" {
+		t.Fatalf("unexpected tree for bytes %q", got)
+	}
+}
+
+func TestRenderTreeValueCycle(t *testing.T) {
+	m := map[string]interface{}{"name": "x"}
+	m["self"] = m
+	want := "x\n" +
+		"├── name: x\n" +
+		"└── self: <cycle>\n"
+	if got := RenderTreeValue("x", m, TreeOptions{}); got != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+
+	list := []interface{}{1, nil}
+	list[1] = list
+	if got := RenderTreeValue("l", list, TreeOptions{}); got != "l\n├── [0]: 1\n└── [1]: <cycle>\n" {
+		t.Fatalf("unexpected tree for cyclic slice %q", got)
+	}
+
+	// a pointer shared by siblings is not a cycle, a map holding a pointer to itself is
+	inner := map[string]interface{}{"v": 1}
+	shared := &inner
+	want = "p\n" +
+		"├── a\n" +
+		"│   └── v: 1\n" +
+		"├── b\n" +
+		"│   └── v: 1\n" +
+		"└── c\n" +
+		"    ├── p: <cycle>\n" +
+		"    └── v: 1\n"
+	ptrs := map[string]interface{}{"a": shared, "b": shared, "c": shared}
+	cyclic := map[string]interface{}{"v": 1}
+	cyclic["p"] = &cyclic
+	ptrs["c"] = &cyclic
+	if got := RenderTreeValue("p", ptrs, TreeOptions{}); got != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+
+	// children past MaxDepth are not built
+	deep := map[string]interface{}{"a": map[string]interface{}{"b": m}}
+	want = "d\n" +
+		"└── a\n" +
+		"    └── b\n"
+	if got := RenderTreeValue("d", deep, TreeOptions{MaxDepth: 2}); got != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+}
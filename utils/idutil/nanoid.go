@@ -0,0 +1,86 @@
+package idutil
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ekreke/gobase/utils/stringutil"
+)
+
+const (
+	// NanoIDAlphabet is the default URL safe alphabet of NanoID
+	NanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// DefaultNanoIDSize is the default length of NanoID, about as collision resistant as UUID v4
+	DefaultNanoIDSize = 21
+)
+
+// ErrInvalidNanoID is returned for a malformed NanoID or an invalid alphabet
+var ErrInvalidNanoID = errors.New("idutil: invalid nanoid")
+
+// NewNanoID generate a NanoID of DefaultNanoIDSize characters from NanoIDAlphabet
+func NewNanoID() (string, error) {
+	return NewNanoIDFrom(rand.Reader, NanoIDAlphabet, DefaultNanoIDSize)
+}
+
+// NewNanoIDWith generate a NanoID of size characters from a custom alphabet
+// exp: NewNanoIDWith("0123456789abcdef", 12)
+func NewNanoIDWith(alphabet string, size int) (string, error) {
+	return NewNanoIDFrom(rand.Reader, alphabet, size)
+}
+
+// NewNanoIDFrom generate a NanoID of size characters from alphabet and src
+func NewNanoIDFrom(src stringutil.RandomSource, alphabet string, size int) (string, error) {
+	if size <= 0 {
+		return "", fmt.Errorf("%w: size %d", ErrInvalidNanoID, size)
+	}
+	if err := validateAlphabet(alphabet); err != nil {
+		return "", err
+	}
+	return stringutil.SecureRandomStringFrom(src, size, alphabet)
+}
+
+// ValidateNanoID check that id has size characters from alphabet, size 0 accept any length
+func ValidateNanoID(id, alphabet string, size int) error {
+	if err := validateAlphabet(alphabet); err != nil {
+		return err
+	}
+	n := utf8.RuneCountInString(id)
+	if n == 0 || (size > 0 && n != size) {
+		return fmt.Errorf("%w: %q has length %d", ErrInvalidNanoID, id, n)
+	}
+	for _, r := range id {
+		if !containsRune(alphabet, r) {
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidNanoID, id, r)
+		}
+	}
+	return nil
+}
+
+// validateAlphabet check that alphabet has at least 2 distinct characters and no duplicate
+func validateAlphabet(alphabet string) error {
+	if !utf8.ValidString(alphabet) {
+		return fmt.Errorf("%w: alphabet is not valid utf-8", ErrInvalidNanoID)
+	}
+	seen := make(map[rune]struct{}, len(alphabet))
+	for _, r := range alphabet {
+		if _, ok := seen[r]; ok {
+			return fmt.Errorf("%w: duplicate %q in alphabet", ErrInvalidNanoID, r)
+		}
+		seen[r] = struct{}{}
+	}
+	if len(seen) < 2 {
+		return fmt.Errorf("%w: alphabet needs at least 2 characters", ErrInvalidNanoID)
+	}
+	return nil
+}
+
+func containsRune(s string, r rune) bool {
+	for _, c := range s {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
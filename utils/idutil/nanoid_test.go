@@ -0,0 +1,63 @@
+package idutil
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewNanoID(t *testing.T) {
+	id, err := NewNanoID()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateNanoID(id, NanoIDAlphabet, DefaultNanoIDSize); err != nil {
+		t.Fatalf("generated %q is invalid: %v", id, err)
+	}
+
+	id, err = NewNanoIDWith("0123456789abcdef", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateNanoID(id, "0123456789abcdef", 12); err != nil {
+		t.Fatalf("generated %q is invalid: %v", id, err)
+	}
+}
+
+func TestNanoIDInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+		size     int
+	}{
+		{name: "zeroSize", alphabet: NanoIDAlphabet, size: 0},
+		{name: "singleChar", alphabet: "a", size: 10},
+		{name: "duplicate", alphabet: "abca", size: 10},
+		{name: "invalidUTF8", alphabet: "ab\xff", size: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNanoIDWith(tt.alphabet, tt.size); !errors.Is(err, ErrInvalidNanoID) {
+				t.Fatalf("expected ErrInvalidNanoID, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateNanoID(t *testing.T) {
+	tests := []struct {
+		in    string
+		size  int
+		valid bool
+	}{
+		{in: "V1StGXR8_Z5jdHi6B-myT", size: 21, valid: true},
+		{in: "V1StGXR8_Z5jdHi6B-myT", size: 0, valid: true},
+		{in: "V1StGXR8_Z5jdHi6B-my", size: 21, valid: false},
+		{in: "V1StGXR8_Z5jdHi6B+myT", size: 21, valid: false},
+		{in: "", size: 0, valid: false},
+	}
+	for _, tt := range tests {
+		if err := ValidateNanoID(tt.in, NanoIDAlphabet, tt.size); (err == nil) != tt.valid {
+			t.Fatalf("ValidateNanoID(%q, %d) = %v, expected valid %v", tt.in, tt.size, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package idutil
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultSnowflakeEpoch is the Twitter snowflake epoch, 2010-11-04 01:42:54.657 UTC
+var DefaultSnowflakeEpoch = time.UnixMilli(1288834974657).UTC()
+
+const (
+	// DefaultWorkerBits allow 1024 workers
+	DefaultWorkerBits = 10
+	// DefaultSequenceBits allow 4096 ids per millisecond and worker
+	DefaultSequenceBits = 12
+)
+
+var (
+	// ErrInvalidSnowflake is returned for an invalid configuration or id
+	ErrInvalidSnowflake = errors.New("idutil: invalid snowflake")
+	// ErrClockBackwards is returned when the clock goes back before the last generated id
+	ErrClockBackwards = errors.New("idutil: clock moved backwards")
+)
+
+// SnowflakeConfig is the layout of snowflake ids: a sign bit, the milliseconds since Epoch,
+// the worker id and a per millisecond sequence, zero fields use the defaults
+type SnowflakeConfig struct {
+	// Epoch is the start of the timestamp, DefaultSnowflakeEpoch if zero
+	Epoch time.Time
+	// WorkerBits is the number of bits of the worker id, DefaultWorkerBits if zero
+	WorkerBits uint
+	// SequenceBits is the number of bits of the sequence, DefaultSequenceBits if zero
+	SequenceBits uint
+}
+
+// SnowflakeID is a parsed snowflake id
+type SnowflakeID struct {
+	Time     time.Time
+	Worker   int64
+	Sequence int64
+}
+
+// Snowflake generate 63 bits time ordered ids for one worker, it is safe for concurrent use
+type Snowflake struct {
+	mu       sync.Mutex
+	cfg      SnowflakeConfig
+	worker   int64
+	lastTime int64
+	sequence int64
+	now      func() time.Time
+}
+
+// NewSnowflake create a generator for worker with cfg
+// exp: NewSnowflake(3, SnowflakeConfig{WorkerBits: 5, SequenceBits: 8})
+func NewSnowflake(worker int64, cfg SnowflakeConfig) (*Snowflake, error) {
+	cfg = cfg.withDefaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if worker < 0 || worker > cfg.maxWorker() {
+		return nil, fmt.Errorf("%w: worker %d out of range [0, %d]", ErrInvalidSnowflake, worker, cfg.maxWorker())
+	}
+	return &Snowflake{cfg: cfg, worker: worker, lastTime: -1, now: time.Now}, nil
+}
+
+// Next generate the next id, when the sequence is exhausted it wait for the next millisecond
+func (s *Snowflake) Next() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := s.elapsed()
+	if ts < 0 || ts > s.cfg.maxTime() {
+		return 0, fmt.Errorf("%w: time %d out of range", ErrInvalidSnowflake, ts)
+	}
+	if ts < s.lastTime {
+		return 0, fmt.Errorf("%w: by %v", ErrClockBackwards, time.Duration(s.lastTime-ts)*time.Millisecond)
+	}
+	if ts == s.lastTime {
+		s.sequence = (s.sequence + 1) & s.cfg.maxSequence()
+		if s.sequence == 0 {
+			for ts <= s.lastTime {
+				time.Sleep(100 * time.Microsecond)
+				ts = s.elapsed()
+				if ts < s.lastTime {
+					// keep the sequence exhausted so a retry in the same millisecond waits again
+					s.sequence = s.cfg.maxSequence()
+					return 0, fmt.Errorf("%w: by %v", ErrClockBackwards, time.Duration(s.lastTime-ts)*time.Millisecond)
+				}
+			}
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastTime = ts
+	return ts<<(s.cfg.WorkerBits+s.cfg.SequenceBits) | s.worker<<s.cfg.SequenceBits | s.sequence, nil
+}
+
+// Parse split an id generated with the same configuration
+func (s *Snowflake) Parse(id int64) (SnowflakeID, error) {
+	return s.cfg.Parse(id)
+}
+
+// Parse split id into its timestamp, worker and sequence
+func (c SnowflakeConfig) Parse(id int64) (SnowflakeID, error) {
+	c = c.withDefaults()
+	if err := c.validate(); err != nil {
+		return SnowflakeID{}, err
+	}
+	if id < 0 {
+		return SnowflakeID{}, fmt.Errorf("%w: negative id %d", ErrInvalidSnowflake, id)
+	}
+	ts := id >> (c.WorkerBits + c.SequenceBits)
+	return SnowflakeID{
+		Time:     c.Epoch.Add(time.Duration(ts) * time.Millisecond),
+		Worker:   id >> c.SequenceBits & c.maxWorker(),
+		Sequence: id & c.maxSequence(),
+	}, nil
+}
+
+func (s *Snowflake) elapsed() int64 {
+	return s.now().Sub(s.cfg.Epoch).Milliseconds()
+}
+
+func (c SnowflakeConfig) withDefaults() SnowflakeConfig {
+	if c.Epoch.IsZero() {
+		c.Epoch = DefaultSnowflakeEpoch
+	}
+	if c.WorkerBits == 0 {
+		c.WorkerBits = DefaultWorkerBits
+	}
+	if c.SequenceBits == 0 {
+		c.SequenceBits = DefaultSequenceBits
+	}
+	return c
+}
+
+// validate keep at least 32 bits for the timestamp
+func (c SnowflakeConfig) validate() error {
+	if c.WorkerBits+c.SequenceBits > 31 {
+		return fmt.Errorf("%w: %d worker bits and %d sequence bits exceed 31", ErrInvalidSnowflake, c.WorkerBits, c.SequenceBits)
+	}
+	return nil
+}
+
+func (c SnowflakeConfig) maxWorker() int64 {
+	return 1<<c.WorkerBits - 1
+}
+
+func (c SnowflakeConfig) maxSequence() int64 {
+	return 1<<c.SequenceBits - 1
+}
+
+func (c SnowflakeConfig) maxTime() int64 {
+	return 1<<(63-c.WorkerBits-c.SequenceBits) - 1
+}
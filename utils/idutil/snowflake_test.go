@@ -0,0 +1,164 @@
+package idutil
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSnowflakeNext(t *testing.T) {
+	now := DefaultSnowflakeEpoch.Add(time.Hour)
+	s, err := NewSnowflake(5, SnowflakeConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.now = func() time.Time { return now }
+
+	first, err := s.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := int64(3600000)<<22 | 5<<12; first != want {
+		t.Fatalf("expected %d, got %d", want, first)
+	}
+	second, _ := s.Next()
+	if second != first+1 {
+		t.Fatalf("expected sequence increment, got %d after %d", second, first)
+	}
+
+	got, err := s.Parse(second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Time.Equal(now) || got.Worker != 5 || got.Sequence != 1 {
+		t.Fatalf("unexpected parse result %+v", got)
+	}
+
+	now = now.Add(-time.Millisecond)
+	if _, err := s.Next(); !errors.Is(err, ErrClockBackwards) {
+		t.Fatalf("expected ErrClockBackwards, got %v", err)
+	}
+}
+
+func TestSnowflakeClockBackwardsWhileWaiting(t *testing.T) {
+	now := DefaultSnowflakeEpoch.Add(time.Hour)
+	s, err := NewSnowflake(1, SnowflakeConfig{SequenceBits: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.now = func() time.Time { return now }
+	for i := 0; i < 2; i++ {
+		if _, err := s.Next(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// the sequence is exhausted, the clock steps back while Next waits for the next millisecond
+	calls := 0
+	s.now = func() time.Time {
+		calls++
+		if calls == 1 {
+			return now
+		}
+		return now.Add(-time.Second)
+	}
+	if _, err := s.Next(); !errors.Is(err, ErrClockBackwards) {
+		t.Fatalf("expected ErrClockBackwards, got %v", err)
+	}
+
+	// a retry in the same millisecond must not reuse a sequence number
+	var reads atomic.Int64
+	s.now = func() time.Time {
+		// the clock moves to the next millisecond after a few reads
+		if reads.Add(1) > 3 {
+			return now.Add(time.Millisecond)
+		}
+		return now
+	}
+	id, err := s.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := s.Parse(id); !got.Time.Equal(now.Add(time.Millisecond)) || got.Sequence != 0 {
+		t.Fatalf("expected the next millisecond, got %+v", got)
+	}
+}
+
+func TestSnowflakeCustomConfig(t *testing.T) {
+	cfg := SnowflakeConfig{Epoch: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), WorkerBits: 4, SequenceBits: 2}
+	s, err := NewSnowflake(15, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := make(map[int64]bool)
+	var prev int64
+	// 4 ids per millisecond, the generator wait for the next millisecond
+	for i := 0; i < 20; i++ {
+		id, err := s.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ids[id] || id <= prev {
+			t.Fatalf("expected increasing unique ids, got %d after %d", id, prev)
+		}
+		ids[id] = true
+		prev = id
+		parsed, err := cfg.Parse(id)
+		if err != nil || parsed.Worker != 15 || parsed.Sequence > 3 {
+			t.Fatalf("unexpected parse result %+v, %v", parsed, err)
+		}
+		if d := time.Since(parsed.Time); d < 0 || d > time.Second {
+			t.Fatalf("unexpected time %v", parsed.Time)
+		}
+	}
+}
+
+func TestSnowflakeInvalid(t *testing.T) {
+	if _, err := NewSnowflake(1024, SnowflakeConfig{}); !errors.Is(err, ErrInvalidSnowflake) {
+		t.Fatalf("expected ErrInvalidSnowflake for worker out of range, got %v", err)
+	}
+	if _, err := NewSnowflake(-1, SnowflakeConfig{}); !errors.Is(err, ErrInvalidSnowflake) {
+		t.Fatalf("expected ErrInvalidSnowflake for negative worker, got %v", err)
+	}
+	if _, err := NewSnowflake(0, SnowflakeConfig{WorkerBits: 20, SequenceBits: 12}); !errors.Is(err, ErrInvalidSnowflake) {
+		t.Fatalf("expected ErrInvalidSnowflake for too many bits, got %v", err)
+	}
+	if _, err := (SnowflakeConfig{}).Parse(-1); !errors.Is(err, ErrInvalidSnowflake) {
+		t.Fatalf("expected ErrInvalidSnowflake for negative id, got %v", err)
+	}
+
+	s, _ := NewSnowflake(0, SnowflakeConfig{})
+	s.now = func() time.Time { return DefaultSnowflakeEpoch.Add(-time.Second) }
+	if _, err := s.Next(); !errors.Is(err, ErrInvalidSnowflake) {
+		t.Fatalf("expected ErrInvalidSnowflake before epoch, got %v", err)
+	}
+}
+
+func TestSnowflakeConcurrent(t *testing.T) {
+	s, _ := NewSnowflake(1, SnowflakeConfig{})
+	var mu sync.Mutex
+	ids := make(map[int64]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				id, err := s.Next()
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				mu.Lock()
+				ids[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(ids) != 8000 {
+		t.Fatalf("expected 8000 unique ids, got %d", len(ids))
+	}
+}
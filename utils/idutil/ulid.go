@@ -0,0 +1,181 @@
+package idutil
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ekreke/gobase/utils/stringutil"
+)
+
+// crockfordAlphabet is the Crockford base32 alphabet used by ULID
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// maxULIDTime is the largest timestamp held by the 48 bits of a ULID
+const maxULIDTime = 1<<48 - 1
+
+var (
+	// ErrInvalidULID is returned when parsing a malformed ULID
+	ErrInvalidULID = errors.New("idutil: invalid ulid")
+	// ErrULIDOverflow is returned when the random part of a ULID overflows within a millisecond
+	ErrULIDOverflow = errors.New("idutil: ulid random part overflow")
+)
+
+// crockfordDecode map a character to its 5 bits value, 0xff for invalid characters
+var crockfordDecode = func() [256]byte {
+	var d [256]byte
+	for i := range d {
+		d[i] = 0xff
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		c := crockfordAlphabet[i]
+		d[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			d[c+'a'-'A'] = byte(i)
+		}
+	}
+	return d
+}()
+
+// ULID is a lexicographically sortable identifier: 48 bits of unix milliseconds followed by 80 random bits
+type ULID [16]byte
+
+// defaultULIDGenerator is used by NewULID
+var defaultULIDGenerator = NewULIDGenerator(nil)
+
+// NewULID generate a monotonic ULID with the default generator
+func NewULID() (ULID, error) {
+	return defaultULIDGenerator.New()
+}
+
+// ULIDGenerator generate ULIDs that are strictly increasing, within a millisecond the random part
+// of the previous ULID is incremented, it is safe for concurrent use
+type ULIDGenerator struct {
+	mu     sync.Mutex
+	src    stringutil.RandomSource
+	now    func() time.Time
+	lastMs uint64
+	last   ULID
+}
+
+// NewULIDGenerator create a generator reading from src, crypto/rand if nil
+func NewULIDGenerator(src stringutil.RandomSource) *ULIDGenerator {
+	if src == nil {
+		src = rand.Reader
+	}
+	return &ULIDGenerator{src: src, now: time.Now}
+}
+
+// New generate a ULID greater than all the ULIDs generated before by g
+func (g *ULIDGenerator) New() (ULID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ms := uint64(g.now().UnixMilli())
+	if ms > maxULIDTime {
+		return ULID{}, fmt.Errorf("%w: time %d out of range", ErrInvalidULID, ms)
+	}
+	// a clock going backwards keep the last time so the order is preserved
+	if ms <= g.lastMs && g.last != (ULID{}) {
+		next := g.last
+		if !incrementBytes(next[6:]) {
+			return ULID{}, ErrULIDOverflow
+		}
+		g.last = next
+		return next, nil
+	}
+	var u ULID
+	if _, err := io.ReadFull(g.src, u[6:]); err != nil {
+		return ULID{}, fmt.Errorf("idutil: read random source: %w", err)
+	}
+	u.setTime(ms)
+	g.lastMs, g.last = ms, u
+	return u, nil
+}
+
+// ParseULID parse a 26 characters ULID, case insensitive
+// exp: "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != 26 {
+		return u, fmt.Errorf("%w: %q", ErrInvalidULID, s)
+	}
+	// 26 characters hold 130 bits, the first one must not exceed 7
+	if crockfordDecode[s[0]] > 7 {
+		return u, fmt.Errorf("%w: %q", ErrInvalidULID, s)
+	}
+	var hi, lo uint64
+	for i := 0; i < 26; i++ {
+		v := crockfordDecode[s[i]]
+		if v == 0xff {
+			return ULID{}, fmt.Errorf("%w: %q", ErrInvalidULID, s)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	for i := 0; i < 8; i++ {
+		u[i] = byte(hi >> (56 - 8*i))
+		u[8+i] = byte(lo >> (56 - 8*i))
+	}
+	return u, nil
+}
+
+// String return the 26 characters Crockford base32 form of the ULID
+func (u ULID) String() string {
+	var hi, lo uint64
+	for i := 0; i < 8; i++ {
+		hi = hi<<8 | uint64(u[i])
+		lo = lo<<8 | uint64(u[8+i])
+	}
+	var buf [26]byte
+	for i := 25; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+// Time return the timestamp embedded in the ULID
+func (u ULID) Time() time.Time {
+	var ms uint64
+	for _, b := range u[:6] {
+		ms = ms<<8 | uint64(b)
+	}
+	return time.UnixMilli(int64(ms))
+}
+
+// MarshalText implement encoding.TextMarshaler
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implement encoding.TextUnmarshaler
+func (u *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+func (u *ULID) setTime(ms uint64) {
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// incrementBytes add one to the big endian number b, it return false on overflow
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
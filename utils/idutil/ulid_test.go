@@ -0,0 +1,101 @@
+package idutil
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestULIDString(t *testing.T) {
+	in := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	u, err := ParseULID(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.String() != in {
+		t.Fatalf("expected %s, got %s", in, u)
+	}
+	if want := time.UnixMilli(1469922850259); !u.Time().Equal(want) {
+		t.Fatalf("expected time %v, got %v", want, u.Time())
+	}
+	lower, err := ParseULID(strings.ToLower(in))
+	if err != nil || lower != u {
+		t.Fatalf("expected case insensitive parse, got %s, %v", lower, err)
+	}
+}
+
+func TestParseULIDInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAVX",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV",
+	} {
+		if _, err := ParseULID(in); !errors.Is(err, ErrInvalidULID) {
+			t.Fatalf("ParseULID(%q) expected ErrInvalidULID, got %v", in, err)
+		}
+	}
+	if _, err := ParseULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ"); err != nil {
+		t.Fatalf("expected max ulid to parse, got %v", err)
+	}
+}
+
+func TestULIDGeneratorMonotonic(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	g := NewULIDGenerator(bytes.NewReader(append(bytes.Repeat([]byte{0}, 9), 0xfe)))
+	g.now = func() time.Time { return now }
+
+	first, err := g.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := g.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.String() <= first.String() || !second.Time().Equal(now) {
+		t.Fatalf("expected %s after %s in the same millisecond", second, first)
+	}
+	if second[15] != 0xff {
+		t.Fatalf("expected random part incremented, got %x", second[6:])
+	}
+
+	// a clock going backwards keep the order
+	now = now.Add(-time.Second)
+	third, err := g.New()
+	if err != nil || third.String() <= second.String() {
+		t.Fatalf("expected %s after %s, %v", third, second, err)
+	}
+}
+
+func TestULIDGeneratorOverflow(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	g := NewULIDGenerator(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)))
+	g.now = func() time.Time { return now }
+	if _, err := g.New(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := g.New(); !errors.Is(err, ErrULIDOverflow) {
+		t.Fatalf("expected ErrULIDOverflow, got %v", err)
+	}
+}
+
+func TestNewULID(t *testing.T) {
+	prev, err := NewULID()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 1000; i++ {
+		next, err := NewULID()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if next.String() <= prev.String() {
+			t.Fatalf("expected %s after %s", next, prev)
+		}
+		prev = next
+	}
+}
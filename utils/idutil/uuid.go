@@ -0,0 +1,148 @@
+package idutil
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ekreke/gobase/utils/stringutil"
+)
+
+// ErrInvalidUUID is returned when parsing a malformed UUID
+var ErrInvalidUUID = errors.New("idutil: invalid uuid")
+
+// UUID is a RFC 9562 universally unique identifier
+type UUID [16]byte
+
+// NilUUID is the UUID with all bits set to zero
+var NilUUID UUID
+
+// NewUUIDv4 generate a random UUID from crypto/rand
+func NewUUIDv4() (UUID, error) {
+	return NewUUIDv4From(rand.Reader)
+}
+
+// NewUUIDv4From generate a random UUID from src
+func NewUUIDv4From(src stringutil.RandomSource) (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(src, u[:]); err != nil {
+		return NilUUID, fmt.Errorf("idutil: read random source: %w", err)
+	}
+	u.setVersion(4)
+	return u, nil
+}
+
+// NewUUIDv7 generate a time ordered UUID from the current time and crypto/rand
+func NewUUIDv7() (UUID, error) {
+	return NewUUIDv7From(rand.Reader, time.Now())
+}
+
+// NewUUIDv7From generate a time ordered UUID from t and src,
+// the first 48 bits are the unix milliseconds of t and the others are random
+func NewUUIDv7From(src stringutil.RandomSource, t time.Time) (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(src, u[6:]); err != nil {
+		return NilUUID, fmt.Errorf("idutil: read random source: %w", err)
+	}
+	ms := uint64(t.UnixMilli())
+	u[0], u[1], u[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+	u[3], u[4], u[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+	u.setVersion(7)
+	return u, nil
+}
+
+// ParseUUID parse a UUID in the canonical form, with or without hyphens, braces or the "urn:uuid:" prefix
+// exp: "f47ac10b-58cc-4372-a567-0e02b2c3d479", "{f47ac10b-58cc-4372-a567-0e02b2c3d479}"
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	str := s
+	switch {
+	case len(str) == 45 && strings.EqualFold(str[:9], "urn:uuid:"):
+		str = str[9:]
+	case len(str) == 38 && str[0] == '{' && str[37] == '}':
+		str = str[1:37]
+	}
+	switch len(str) {
+	case 36:
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return NilUUID, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+		}
+		str = str[:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	case 32:
+	default:
+		return NilUUID, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	if _, err := hex.Decode(u[:], []byte(str)); err != nil {
+		return NilUUID, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	return u, nil
+}
+
+// MustParseUUID parse a UUID like ParseUUID and panic on error, for constants
+func MustParseUUID(s string) UUID {
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// String return the canonical form of the UUID, exp: "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Version return the version number of the UUID, exp: 4 or 7
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// IsRFC check if the UUID has the RFC 9562 variant
+func (u UUID) IsRFC() bool {
+	return u[8]&0xc0 == 0x80
+}
+
+// Time return the timestamp embedded in a version 7 UUID, ok is false for other versions
+func (u UUID) Time() (t time.Time, ok bool) {
+	if u.Version() != 7 || !u.IsRFC() {
+		return time.Time{}, false
+	}
+	var buf [8]byte
+	copy(buf[2:], u[:6])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(buf[:]))), true
+}
+
+// MarshalText implement encoding.TextMarshaler
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implement encoding.TextUnmarshaler
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+func (u *UUID) setVersion(version byte) {
+	u[6] = u[6]&0x0f | version<<4
+	u[8] = u[8]&0x3f | 0x80
+}
@@ -0,0 +1,107 @@
+package idutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewUUIDv4(t *testing.T) {
+	seen := make(map[UUID]bool)
+	for i := 0; i < 1000; i++ {
+		u, err := NewUUIDv4()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if u.Version() != 4 || !u.IsRFC() {
+			t.Fatalf("expected RFC version 4, got %s", u)
+		}
+		if seen[u] {
+			t.Fatalf("duplicate uuid %s", u)
+		}
+		seen[u] = true
+	}
+
+	u, err := NewUUIDv4From(bytes.NewReader(make([]byte, 16)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "00000000-0000-4000-8000-000000000000"; u.String() != want {
+		t.Fatalf("expected %s, got %s", want, u)
+	}
+	if _, err := NewUUIDv4From(bytes.NewReader(nil)); err == nil {
+		t.Fatalf("expected error for empty source")
+	}
+}
+
+func TestNewUUIDv7(t *testing.T) {
+	now := time.UnixMilli(1700000000123)
+	u, err := NewUUIDv7From(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "018bcfe5-687b-7fff-bfff-ffffffffffff"; u.String() != want {
+		t.Fatalf("expected %s, got %s", want, u)
+	}
+	got, ok := u.Time()
+	if !ok || !got.Equal(now) {
+		t.Fatalf("expected time %v, got %v %v", now, got, ok)
+	}
+
+	prev, _ := NewUUIDv7()
+	time.Sleep(2 * time.Millisecond)
+	next, _ := NewUUIDv7()
+	if next.String() <= prev.String() {
+		t.Fatalf("expected %s after %s", next, prev)
+	}
+	if _, ok := MustParseUUID("f47ac10b-58cc-4372-a567-0e02b2c3d479").Time(); ok {
+		t.Fatalf("expected no time for version 4")
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	want := MustParseUUID("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	tests := []struct {
+		in    string
+		valid bool
+	}{
+		{in: "f47ac10b-58cc-4372-a567-0e02b2c3d479", valid: true},
+		{in: "F47AC10B-58CC-4372-A567-0E02B2C3D479", valid: true},
+		{in: "f47ac10b58cc4372a5670e02b2c3d479", valid: true},
+		{in: "{f47ac10b-58cc-4372-a567-0e02b2c3d479}", valid: true},
+		{in: "urn:uuid:f47ac10b-58cc-4372-a567-0e02b2c3d479", valid: true},
+		{in: "f47ac10b-58cc-4372-a567-0e02b2c3d47", valid: false},
+		{in: "f47ac10b_58cc_4372_a567_0e02b2c3d479", valid: false},
+		{in: "g47ac10b-58cc-4372-a567-0e02b2c3d479", valid: false},
+		{in: "", valid: false},
+	}
+	for _, tt := range tests {
+		got, err := ParseUUID(tt.in)
+		if !tt.valid {
+			if !errors.Is(err, ErrInvalidUUID) {
+				t.Fatalf("ParseUUID(%q) expected ErrInvalidUUID, got %v", tt.in, err)
+			}
+			continue
+		}
+		if err != nil || got != want {
+			t.Fatalf("ParseUUID(%q) = %s, %v, expected %s", tt.in, got, err, want)
+		}
+	}
+}
+
+func TestUUIDText(t *testing.T) {
+	u := MustParseUUID("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	data, err := json.Marshal(map[string]UUID{"id": u})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"id":"f47ac10b-58cc-4372-a567-0e02b2c3d479"}` {
+		t.Fatalf("unexpected json %s", data)
+	}
+	var got map[string]UUID
+	if err := json.Unmarshal(data, &got); err != nil || got["id"] != u {
+		t.Fatalf("unexpected round trip %v, %v", got, err)
+	}
+}
@@ -0,0 +1,522 @@
+package maputil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnknownKey is returned in strict mode for input keys matching no struct field
+	ErrUnknownKey = errors.New("maputil: unknown key")
+	// ErrInvalidTarget is returned when the decode target is not a non-nil pointer
+	ErrInvalidTarget = errors.New("maputil: decode target must be a non-nil pointer")
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// DecodeHook convert data before it is decoded into a value of type to, exp: parse a custom format,
+// it return data unchanged when it does not apply
+type DecodeHook func(from, to reflect.Type, data interface{}) (interface{}, error)
+
+// DecoderConfig control how a Decoder fill structs from maps
+type DecoderConfig struct {
+	// TagName is the struct tag giving field names, DefaultTagName if empty
+	TagName string
+	// WeaklyTyped convert between strings, numbers and bools, and parse strings into
+	// time.Duration and time.Time, exp: "8080" -> 8080, "1" -> true, "5s" -> 5*time.Second
+	WeaklyTyped bool
+	// Strict reject input keys matching no struct field with ErrUnknownKey
+	Strict bool
+	// TimeLayout is the layout of strings decoded into time.Time, time.RFC3339 if empty
+	TimeLayout string
+	// Hooks are applied in order to every value before it is decoded
+	Hooks []DecodeHook
+}
+
+// FieldError is a decode error of the value at Path, exp: "servers[0].port"
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+// Error implement error
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return "maputil: decode: " + e.Err.Error()
+	}
+	return "maputil: decode " + e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap return the underlying error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Decoder fill structs from maps without a JSON round trip
+type Decoder struct {
+	cfg DecoderConfig
+}
+
+// NewDecoder create a decoder with cfg
+func NewDecoder(cfg DecoderConfig) *Decoder {
+	if cfg.TagName == "" {
+		cfg.TagName = DefaultTagName
+	}
+	if cfg.TimeLayout == "" {
+		cfg.TimeLayout = time.RFC3339
+	}
+	return &Decoder{cfg: cfg}
+}
+
+// Decode decode input into out, a non-nil pointer, every failing field is reported
+// as a *FieldError joined in the returned error
+func (d *Decoder) Decode(input map[string]interface{}, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("%w, got %T", ErrInvalidTarget, out)
+	}
+	return d.decode("", input, v.Elem())
+}
+
+// MapToStructWith convert map to struct with a Decoder configured by cfg
+// exp: MapToStructWith[Config](map[string]interface{}{"port": "8080"}, DecoderConfig{WeaklyTyped: true})
+func MapToStructWith[T any](m map[string]interface{}, cfg DecoderConfig) (out T, err error) {
+	err = NewDecoder(cfg).Decode(m, &out)
+	return out, err
+}
+
+// StringToSliceHook split strings decoded into slices with sep, exp: "a,b" -> []string{"a", "b"}
+func StringToSliceHook(sep string) DecodeHook {
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		s, ok := data.(string)
+		if !ok || to.Kind() != reflect.Slice || to.Elem().Kind() == reflect.Uint8 {
+			return data, nil
+		}
+		if s == "" {
+			return []string{}, nil
+		}
+		return strings.Split(s, sep), nil
+	}
+}
+
+func (d *Decoder) decode(path string, data interface{}, out reflect.Value) error {
+	for _, hook := range d.cfg.Hooks {
+		var err error
+		if data, err = hook(reflect.TypeOf(data), out.Type(), data); err != nil {
+			return &FieldError{Path: path, Err: err}
+		}
+	}
+	if data == nil {
+		out.Set(reflect.Zero(out.Type()))
+		return nil
+	}
+	in := reflect.ValueOf(data)
+	// maps and slices are walked when there are hooks so they also apply to the elements,
+	// structs, time.Time and pointers are assigned as is
+	if in.Type().AssignableTo(out.Type()) && (len(d.cfg.Hooks) == 0 || !isContainer(out.Kind())) {
+		out.Set(in)
+		return nil
+	}
+	var err error
+	switch {
+	case out.Type() == durationType:
+		err = d.decodeDuration(data, out)
+	case out.Type() == timeType:
+		err = d.decodeTime(data, out)
+	default:
+		switch out.Kind() {
+		case reflect.Pointer:
+			elem := reflect.New(out.Type().Elem())
+			if err := d.decode(path, data, elem.Elem()); err != nil {
+				return err
+			}
+			out.Set(elem)
+			return nil
+		case reflect.Struct:
+			return d.decodeStruct(path, in, out)
+		case reflect.Map:
+			return d.decodeMap(path, in, out)
+		case reflect.Slice, reflect.Array:
+			return d.decodeSlice(path, in, out)
+		case reflect.Interface:
+			err = mismatch(data, out)
+		case reflect.String:
+			err = d.decodeString(in, out)
+		case reflect.Bool:
+			err = d.decodeBool(in, out)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			err = d.decodeInt(in, out)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			err = d.decodeUint(in, out)
+		case reflect.Float32, reflect.Float64:
+			err = d.decodeFloat(in, out)
+		default:
+			err = mismatch(data, out)
+		}
+	}
+	if err != nil {
+		return &FieldError{Path: path, Err: err}
+	}
+	return nil
+}
+
+func (d *Decoder) decodeStruct(path string, in reflect.Value, out reflect.Value) error {
+	if in.Kind() == reflect.Pointer && !in.IsNil() {
+		in = in.Elem()
+	}
+	if in.Kind() == reflect.Struct {
+		// decode a struct of another type through its map form
+		in = reflect.ValueOf(structToMap(in, d.cfg.TagName))
+	}
+	if in.Kind() != reflect.Map || in.Type().Key().Kind() != reflect.String {
+		return &FieldError{Path: path, Err: mismatch(in.Interface(), out)}
+	}
+	plans := structPlan(out.Type(), d.cfg.TagName)
+	keys := make(map[string]reflect.Value, in.Len())
+	iter := in.MapRange()
+	for iter.Next() {
+		keys[iter.Key().String()] = iter.Value()
+	}
+	used := make(map[string]bool, len(keys))
+	var errs []error
+	for _, p := range plans {
+		key, ok := matchKey(keys, p.name)
+		if !ok {
+			continue
+		}
+		field, ok := fieldByIndexAlloc(out, p.index)
+		if !ok {
+			continue
+		}
+		used[key] = true
+		if err := d.decode(joinPath(path, key), keys[key].Interface(), field); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if d.cfg.Strict {
+		for key := range keys {
+			if !used[key] {
+				errs = append(errs, &FieldError{Path: joinPath(path, key), Err: ErrUnknownKey})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// matchKey find the input key of a field, an exact match is preferred over a case insensitive one
+func matchKey(keys map[string]reflect.Value, name string) (string, bool) {
+	if _, ok := keys[name]; ok {
+		return name, true
+	}
+	for k := range keys {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// fieldByIndexAlloc return the field at index, allocating nil embedded pointers on the way,
+// ok is false when a nil embedded pointer can not be set as its type is unexported
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func (d *Decoder) decodeMap(path string, in reflect.Value, out reflect.Value) error {
+	if in.Kind() == reflect.Pointer && !in.IsNil() {
+		in = in.Elem()
+	}
+	if in.Kind() == reflect.Struct {
+		in = reflect.ValueOf(structToMap(in, d.cfg.TagName))
+	}
+	if in.Kind() != reflect.Map {
+		return &FieldError{Path: path, Err: mismatch(in.Interface(), out)}
+	}
+	m := reflect.MakeMapWithSize(out.Type(), in.Len())
+	var errs []error
+	iter := in.MapRange()
+	for iter.Next() {
+		keyPath := joinPath(path, fmt.Sprint(iter.Key().Interface()))
+		key := reflect.New(out.Type().Key()).Elem()
+		if err := d.decode(keyPath, iter.Key().Interface(), key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		elem := reflect.New(out.Type().Elem()).Elem()
+		if err := d.decode(keyPath, iter.Value().Interface(), elem); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.SetMapIndex(key, elem)
+	}
+	out.Set(m)
+	return errors.Join(errs...)
+}
+
+func (d *Decoder) decodeSlice(path string, in reflect.Value, out reflect.Value) error {
+	if in.Kind() == reflect.String && out.Type().Elem().Kind() == reflect.Uint8 && d.cfg.WeaklyTyped {
+		in = reflect.ValueOf([]byte(in.String()))
+	}
+	if in.Kind() != reflect.Slice && in.Kind() != reflect.Array {
+		if !d.cfg.WeaklyTyped {
+			return &FieldError{Path: path, Err: mismatch(in.Interface(), out)}
+		}
+		// a single value is decoded as a one element slice
+		wrapped := reflect.MakeSlice(reflect.SliceOf(in.Type()), 1, 1)
+		wrapped.Index(0).Set(in)
+		in = wrapped
+	}
+	n := in.Len()
+	target := out
+	if out.Kind() == reflect.Slice {
+		target = reflect.MakeSlice(out.Type(), n, n)
+	} else if n > out.Len() {
+		return &FieldError{Path: path, Err: fmt.Errorf("%d elements overflow %s", n, out.Type())}
+	}
+	var errs []error
+	for i := 0; i < n; i++ {
+		if err := d.decode(fmt.Sprintf("%s[%d]", path, i), in.Index(i).Interface(), target.Index(i)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if out.Kind() == reflect.Slice {
+		out.Set(target)
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Decoder) decodeString(in, out reflect.Value) error {
+	switch {
+	case in.Kind() == reflect.String:
+		out.SetString(in.String())
+	case !d.cfg.WeaklyTyped:
+		return mismatch(in.Interface(), out)
+	case in.Kind() == reflect.Bool:
+		out.SetString(strconv.FormatBool(in.Bool()))
+	case in.CanInt():
+		out.SetString(strconv.FormatInt(in.Int(), 10))
+	case in.CanUint():
+		out.SetString(strconv.FormatUint(in.Uint(), 10))
+	case in.CanFloat():
+		out.SetString(strconv.FormatFloat(in.Float(), 'f', -1, 64))
+	case in.Kind() == reflect.Slice && in.Type().Elem().Kind() == reflect.Uint8:
+		out.SetString(string(in.Bytes()))
+	default:
+		return mismatch(in.Interface(), out)
+	}
+	return nil
+}
+
+func (d *Decoder) decodeBool(in, out reflect.Value) error {
+	switch {
+	case in.Kind() == reflect.Bool:
+		out.SetBool(in.Bool())
+	case !d.cfg.WeaklyTyped:
+		return mismatch(in.Interface(), out)
+	case in.CanInt():
+		out.SetBool(in.Int() != 0)
+	case in.CanUint():
+		out.SetBool(in.Uint() != 0)
+	case in.CanFloat():
+		out.SetBool(in.Float() != 0)
+	case in.Kind() == reflect.String:
+		if in.String() == "" {
+			out.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(in.String())
+		if err != nil {
+			return err
+		}
+		out.SetBool(b)
+	default:
+		return mismatch(in.Interface(), out)
+	}
+	return nil
+}
+
+func (d *Decoder) decodeInt(in, out reflect.Value) error {
+	var n int64
+	switch {
+	case in.CanInt():
+		n = in.Int()
+	case in.CanUint():
+		if in.Uint() > math.MaxInt64 {
+			return fmt.Errorf("%d overflows %s", in.Uint(), out.Type())
+		}
+		n = int64(in.Uint())
+	case in.CanFloat():
+		// numbers decoded from JSON are float64
+		f := in.Float()
+		if f != math.Trunc(f) {
+			return fmt.Errorf("%v is not an integer", f)
+		}
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return fmt.Errorf("%v overflows %s", f, out.Type())
+		}
+		n = int64(f)
+	case !d.cfg.WeaklyTyped:
+		return mismatch(in.Interface(), out)
+	case in.Kind() == reflect.Bool:
+		if in.Bool() {
+			n = 1
+		}
+	case in.Kind() == reflect.String:
+		var err error
+		if n, err = parseInt(in.String()); err != nil {
+			return err
+		}
+	default:
+		return mismatch(in.Interface(), out)
+	}
+	if out.OverflowInt(n) {
+		return fmt.Errorf("%d overflows %s", n, out.Type())
+	}
+	out.SetInt(n)
+	return nil
+}
+
+func (d *Decoder) decodeUint(in, out reflect.Value) error {
+	var n uint64
+	switch {
+	case in.CanInt():
+		if in.Int() < 0 {
+			return fmt.Errorf("%d overflows %s", in.Int(), out.Type())
+		}
+		n = uint64(in.Int())
+	case in.CanUint():
+		n = in.Uint()
+	case in.CanFloat():
+		f := in.Float()
+		if f != math.Trunc(f) {
+			return fmt.Errorf("%v is not an integer", f)
+		}
+		if f < 0 || f >= math.MaxUint64 {
+			return fmt.Errorf("%v overflows %s", f, out.Type())
+		}
+		n = uint64(f)
+	case !d.cfg.WeaklyTyped:
+		return mismatch(in.Interface(), out)
+	case in.Kind() == reflect.Bool:
+		if in.Bool() {
+			n = 1
+		}
+	case in.Kind() == reflect.String:
+		i, err := parseInt(in.String())
+		if err != nil {
+			return err
+		}
+		if i < 0 {
+			return fmt.Errorf("%d overflows %s", i, out.Type())
+		}
+		n = uint64(i)
+	default:
+		return mismatch(in.Interface(), out)
+	}
+	if out.OverflowUint(n) {
+		return fmt.Errorf("%d overflows %s", n, out.Type())
+	}
+	out.SetUint(n)
+	return nil
+}
+
+func (d *Decoder) decodeFloat(in, out reflect.Value) error {
+	var f float64
+	switch {
+	case in.CanInt():
+		f = float64(in.Int())
+	case in.CanUint():
+		f = float64(in.Uint())
+	case in.CanFloat():
+		f = in.Float()
+	case !d.cfg.WeaklyTyped:
+		return mismatch(in.Interface(), out)
+	case in.Kind() == reflect.Bool:
+		if in.Bool() {
+			f = 1
+		}
+	case in.Kind() == reflect.String:
+		var err error
+		if f, err = strconv.ParseFloat(strings.TrimSpace(in.String()), 64); err != nil {
+			return err
+		}
+	default:
+		return mismatch(in.Interface(), out)
+	}
+	if out.OverflowFloat(f) {
+		return fmt.Errorf("%v overflows %s", f, out.Type())
+	}
+	out.SetFloat(f)
+	return nil
+}
+
+func (d *Decoder) decodeDuration(data interface{}, out reflect.Value) error {
+	if s, ok := data.(string); ok && d.cfg.WeaklyTyped {
+		dur, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		out.SetInt(int64(dur))
+		return nil
+	}
+	return d.decodeInt(reflect.ValueOf(data), out)
+}
+
+func (d *Decoder) decodeTime(data interface{}, out reflect.Value) error {
+	s, ok := data.(string)
+	if !ok || !d.cfg.WeaklyTyped {
+		return mismatch(data, out)
+	}
+	t, err := time.Parse(d.cfg.TimeLayout, strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	out.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// parseInt parse a decimal integer, integral floats like "1e3" or "8080.0" are accepted
+func parseInt(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+	return int64(f), nil
+}
+
+// isContainer report the kinds whose elements are decoded one by one
+func isContainer(k reflect.Kind) bool {
+	return k == reflect.Map || k == reflect.Slice
+}
+
+func mismatch(data interface{}, out reflect.Value) error {
+	return fmt.Errorf("cannot decode %T into %s", data, out.Type())
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
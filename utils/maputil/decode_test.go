@@ -0,0 +1,226 @@
+package maputil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type decodeServer struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type decodeConfig struct {
+	Name     string            `json:"name"`
+	Debug    bool              `json:"debug"`
+	Rate     float64           `json:"rate"`
+	Workers  uint8             `json:"workers"`
+	Timeout  time.Duration     `json:"timeout"`
+	Started  time.Time         `json:"started"`
+	Servers  []decodeServer    `json:"servers"`
+	Primary  *decodeServer     `json:"primary"`
+	Labels   map[string]string `json:"labels"`
+	Limits   map[string]int    `json:"limits"`
+	Tags     []string          `json:"tags"`
+	Any      interface{}       `json:"any"`
+	Checksum [2]int            `json:"checksum"`
+	structBase
+}
+
+func TestDecoderDecode(t *testing.T) {
+	started := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	input := map[string]interface{}{
+		"name":     "api",
+		"debug":    true,
+		"rate":     0.5,
+		"workers":  float64(4),
+		"timeout":  int64(time.Second),
+		"started":  started,
+		"servers":  []interface{}{map[string]interface{}{"host": "a", "port": float64(80)}},
+		"primary":  map[string]interface{}{"host": "b", "port": 443},
+		"labels":   map[string]interface{}{"env": "prod"},
+		"limits":   map[string]interface{}{"cpu": float64(2)},
+		"tags":     []interface{}{"x", "y"},
+		"any":      []int{1},
+		"checksum": []interface{}{1, 2},
+		"ID":       float64(9),
+	}
+	var cfg decodeConfig
+	if err := NewDecoder(DecoderConfig{}).Decode(input, &cfg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := decodeConfig{
+		Name: "api", Debug: true, Rate: 0.5, Workers: 4, Timeout: time.Second, Started: started,
+		Servers:    []decodeServer{{Host: "a", Port: 80}},
+		Primary:    &decodeServer{Host: "b", Port: 443},
+		Labels:     map[string]string{"env": "prod"},
+		Limits:     map[string]int{"cpu": 2},
+		Tags:       []string{"x", "y"},
+		Any:        []int{1},
+		Checksum:   [2]int{1, 2},
+		structBase: structBase{ID: 9},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Fatalf("expected %+v, got %+v", want, cfg)
+	}
+}
+
+func TestDecoderWeaklyTyped(t *testing.T) {
+	input := map[string]interface{}{
+		"name":    8080,
+		"debug":   "1",
+		"rate":    "0.25",
+		"workers": "8",
+		"timeout": "1m30s",
+		"started": "2024-05-06T07:08:09Z",
+		"servers": map[string]interface{}{"host": "single", "port": "8080"},
+		"tags":    "only",
+	}
+	cfg, err := MapToStructWith[decodeConfig](input, DecoderConfig{WeaklyTyped: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Name != "8080" || !cfg.Debug || cfg.Rate != 0.25 || cfg.Workers != 8 || cfg.Timeout != 90*time.Second {
+		t.Fatalf("unexpected weak conversion %+v", cfg)
+	}
+	if !cfg.Started.Equal(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)) {
+		t.Fatalf("unexpected time %v", cfg.Started)
+	}
+	if len(cfg.Servers) != 1 || cfg.Servers[0].Port != 8080 || !reflect.DeepEqual(cfg.Tags, []string{"only"}) {
+		t.Fatalf("unexpected slices %+v %+v", cfg.Servers, cfg.Tags)
+	}
+
+	if _, err := MapToStructWith[decodeConfig](map[string]interface{}{"workers": "8"}, DecoderConfig{}); err == nil {
+		t.Fatalf("expected error without weak typing")
+	}
+}
+
+func TestDecoderErrorPaths(t *testing.T) {
+	input := map[string]interface{}{
+		"rate":    "fast",
+		"workers": 300,
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a", "port": 80},
+			map[string]interface{}{"host": "b", "port": "http"},
+		},
+		"limits":  map[string]interface{}{"cpu": 1.5},
+		"unknown": 1,
+	}
+	_, err := MapToStructWith[decodeConfig](input, DecoderConfig{WeaklyTyped: true, Strict: true})
+	if err == nil {
+		t.Fatalf("expected errors")
+	}
+	for _, path := range []string{"rate", "workers", "servers[1].port", "limits.cpu", "unknown"} {
+		if !strings.Contains(err.Error(), "decode "+path+":") {
+			t.Fatalf("expected error for %s, got %v", path, err)
+		}
+	}
+	if !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("expected ErrUnknownKey, got %v", err)
+	}
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Path == "" {
+		t.Fatalf("expected FieldError, got %v", err)
+	}
+}
+
+func TestDecoderOptions(t *testing.T) {
+	type tagged struct {
+		UserName string   `mapstructure:"user_name"`
+		Roles    []string `mapstructure:"roles"`
+		Port     int
+	}
+	upper := func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if s, ok := data.(string); ok && to.Kind() == reflect.String {
+			return strings.ToUpper(s), nil
+		}
+		return data, nil
+	}
+	got, err := MapToStructWith[tagged](map[string]interface{}{
+		"user_name": "bob",
+		"roles":     "admin,dev",
+		"PORT":      22,
+	}, DecoderConfig{TagName: "mapstructure", Hooks: []DecodeHook{StringToSliceHook(","), upper}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.UserName != "BOB" || !reflect.DeepEqual(got.Roles, []string{"ADMIN", "DEV"}) || got.Port != 22 {
+		t.Fatalf("unexpected result %+v", got)
+	}
+
+	failing := func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if to.Kind() == reflect.Int {
+			return nil, errors.New("hook failed")
+		}
+		return data, nil
+	}
+	_, err = MapToStructWith[tagged](map[string]interface{}{"Port": 1}, DecoderConfig{Hooks: []DecodeHook{failing}})
+	if err == nil || !strings.Contains(err.Error(), "decode Port: hook failed") {
+		t.Fatalf("expected hook error with path, got %v", err)
+	}
+
+	// values assignable to structs and pointers are kept with hooks set
+	noop := func(from, to reflect.Type, data interface{}) (interface{}, error) { return data, nil }
+	started := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	primary := &decodeServer{Host: "db"}
+	cfg, err := MapToStructWith[decodeConfig](map[string]interface{}{
+		"started": started,
+		"primary": primary,
+		"servers": []decodeServer{{Host: "a"}},
+		"tags":    "x,y",
+	}, DecoderConfig{Hooks: []DecodeHook{noop, StringToSliceHook(",")}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !cfg.Started.Equal(started) || cfg.Primary != primary || cfg.Servers[0].Host != "a" || !reflect.DeepEqual(cfg.Tags, []string{"x", "y"}) {
+		t.Fatalf("unexpected result %+v", cfg)
+	}
+}
+
+func TestDecoderInvalidTarget(t *testing.T) {
+	d := NewDecoder(DecoderConfig{})
+	var cfg decodeConfig
+	for _, out := range []interface{}{nil, cfg, (*decodeConfig)(nil)} {
+		if err := d.Decode(map[string]interface{}{}, out); !errors.Is(err, ErrInvalidTarget) {
+			t.Fatalf("expected ErrInvalidTarget for %T, got %v", out, err)
+		}
+	}
+}
+
+func TestStructRoundTrip(t *testing.T) {
+	in := decodeConfig{Name: "x", Timeout: time.Minute, Servers: []decodeServer{{Host: "h", Port: 1}}, Primary: &decodeServer{Port: 2}}
+	m, err := StructToMapWith(in, StructMapOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	out, err := MapToStructWith[decodeConfig](m, DecoderConfig{Strict: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("expected %+v, got %+v", in, out)
+	}
+}
+
+type DecodeEmbedded struct {
+	Level int `json:"level"`
+}
+
+type decodeEmbeddedPtr struct {
+	*DecodeEmbedded
+	*structBase
+	Name string `json:"name"`
+}
+
+func TestDecoderEmbeddedPointer(t *testing.T) {
+	got, err := MapToStructWith[decodeEmbeddedPtr](map[string]interface{}{"name": "x", "level": 3, "id": 1}, DecoderConfig{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Name != "x" || got.DecodeEmbedded == nil || got.Level != 3 || got.structBase != nil {
+		t.Fatalf("unexpected result %+v", got)
+	}
+}
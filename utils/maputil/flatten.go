@@ -0,0 +1,194 @@
+package maputil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultSeparator join the keys of flattened maps when no separator is set
+const DefaultSeparator = "."
+
+// ErrFlattenCollision is returned when two values end up with the same flat key,
+// or when flat keys of Unflatten need a value to be both a map, a slice or a scalar
+var ErrFlattenCollision = errors.New("maputil: flatten key collision")
+
+// IndexStyle is how slice indexes appear in flat keys
+type IndexStyle int
+
+// slice index styles
+const (
+	// IndexDot write indexes like keys, exp: "servers.0.host"
+	IndexDot IndexStyle = iota
+	// IndexBracket write indexes in brackets, exp: "servers[0].host"
+	IndexBracket
+)
+
+// FlattenOptions control the keys built by Flatten and read by Unflatten
+type FlattenOptions struct {
+	// Separator join nested keys, DefaultSeparator if empty, exp: "_" for env vars
+	Separator  string
+	IndexStyle IndexStyle
+}
+
+func (o FlattenOptions) separator() string {
+	if o.Separator == "" {
+		return DefaultSeparator
+	}
+	return o.Separator
+}
+
+// Flatten turn nested maps and slices into a single level map, empty maps and slices are kept as values,
+// it fails with ErrFlattenCollision when two values get the same key, exp: {"a.b": 1, "a": {"b": 2}}
+// exp: Flatten({"db": {"hosts": ["a", "b"]}}, FlattenOptions{}) -> {"db.hosts.0": "a", "db.hosts.1": "b"}
+func Flatten(m map[string]interface{}, opts FlattenOptions) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if err := flattenValue(out, k, v, opts); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Unflatten rebuild the nested maps and slices of a map made by Flatten with the same options,
+// Unflatten(Flatten(m)) equals m when keys do not contain the separator, are not made of digits
+// with IndexDot or end with brackets with IndexBracket, and slices are []interface{},
+// slice indexes must follow each other from 0, a gap fails with ErrInvalidPath
+func Unflatten(flat map[string]interface{}, opts FlattenOptions) (map[string]interface{}, error) {
+	type flatKey struct {
+		key  string
+		segs []pathSegment
+	}
+	keys := make([]flatKey, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, flatKey{k, opts.split(k)})
+	}
+	// a key sorts before the keys it prefixes, so a scalar is set before values nested under it,
+	// and indexes sort by value so slices are filled in order
+	sort.Slice(keys, func(i, j int) bool { return compareSegments(keys[i].segs, keys[j].segs) < 0 })
+	out := make(map[string]interface{}, len(flat))
+	for _, k := range keys {
+		// setIn replace a nil value or a nil map on the path with a new map or slice, so this guard
+		// is what report a key nested under a nil value, exp: {"a": nil, "a.b": 1}, as a collision
+		for i := 1; i <= len(k.segs); i++ {
+			if v, ok := getIn(out, k.segs[:i]); ok && (isNilValue(v) || i == len(k.segs)) {
+				return nil, fmt.Errorf("%w: %q", ErrFlattenCollision, k.key)
+			}
+		}
+		if _, err := setIn(out, k.segs, deepCopy(flat[k.key]), ""); err != nil {
+			if errors.Is(err, ErrPathType) {
+				return nil, fmt.Errorf("%w: %q: %v", ErrFlattenCollision, k.key, err)
+			}
+			return nil, fmt.Errorf("%w: key %q", err, k.key)
+		}
+	}
+	return out, nil
+}
+
+// isNilValue report nil and nil maps and slices
+func isNilValue(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return v == nil || (rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.IsNil()
+}
+
+// compareSegments order paths segment by segment, keys before indexes and indexes by value
+func compareSegments(a, b []pathSegment) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, y := a[i], b[i]
+		switch {
+		case x.isIndex != y.isIndex:
+			if x.isIndex {
+				return 1
+			}
+			return -1
+		case x.isIndex && x.index != y.index:
+			return x.index - y.index
+		case !x.isIndex && x.key != y.key:
+			return strings.Compare(x.key, y.key)
+		}
+	}
+	return len(a) - len(b)
+}
+
+func flattenValue(out map[string]interface{}, key string, v interface{}, opts FlattenOptions) error {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String && rv.Len() > 0:
+		iter := rv.MapRange()
+		for iter.Next() {
+			if err := flattenValue(out, key+opts.separator()+iter.Key().String(), iter.Value().Interface(), opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 && rv.Len() > 0:
+		for i := 0; i < rv.Len(); i++ {
+			if err := flattenValue(out, opts.index(key, i), rv.Index(i).Interface(), opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if _, ok := out[key]; ok {
+		return fmt.Errorf("%w: %q", ErrFlattenCollision, key)
+	}
+	out[key] = deepCopy(v)
+	return nil
+}
+
+func (o FlattenOptions) index(key string, i int) string {
+	if o.IndexStyle == IndexBracket {
+		return key + "[" + strconv.Itoa(i) + "]"
+	}
+	return key + o.separator() + strconv.Itoa(i)
+}
+
+// split turn a flat key into path segments
+func (o FlattenOptions) split(key string) []pathSegment {
+	var segs []pathSegment
+	for _, part := range strings.Split(key, o.separator()) {
+		if o.IndexStyle == IndexBracket {
+			segs = append(segs, splitBrackets(part)...)
+			continue
+		}
+		if n, ok := parseIndex(part); ok {
+			segs = append(segs, pathSegment{index: n, isIndex: true})
+		} else {
+			segs = append(segs, pathSegment{key: part})
+		}
+	}
+	return segs
+}
+
+// splitBrackets split "hosts[0][1]" into a key and indexes, part is a single key if the brackets are malformed
+func splitBrackets(part string) []pathSegment {
+	i := strings.IndexByte(part, '[')
+	if i < 0 || !strings.HasSuffix(part, "]") {
+		return []pathSegment{{key: part}}
+	}
+	var segs []pathSegment
+	if i > 0 {
+		segs = append(segs, pathSegment{key: part[:i]})
+	}
+	for _, idx := range strings.Split(part[i+1:len(part)-1], "][") {
+		n, ok := parseIndex(idx)
+		if !ok {
+			return []pathSegment{{key: part}}
+		}
+		segs = append(segs, pathSegment{index: n, isIndex: true})
+	}
+	return segs
+}
+
+// parseIndex parse a slice index made of digits only
+func parseIndex(s string) (int, bool) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
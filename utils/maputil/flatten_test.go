@@ -0,0 +1,191 @@
+package maputil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func flattenTestMap() map[string]interface{} {
+	return map[string]interface{}{
+		"name": "app",
+		"db": map[string]interface{}{
+			"host":  "localhost",
+			"port":  5432,
+			"hosts": []interface{}{"a", "b"},
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "x", "tags": []interface{}{"web"}},
+			map[string]interface{}{"host": "y"},
+		},
+		"extra": map[string]interface{}{},
+		"list":  []interface{}{},
+		"nil":   nil,
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name string
+		opts FlattenOptions
+		want map[string]interface{}
+	}{
+		{"dot", FlattenOptions{}, map[string]interface{}{
+			"name":             "app",
+			"db.host":          "localhost",
+			"db.port":          5432,
+			"db.hosts.0":       "a",
+			"db.hosts.1":       "b",
+			"servers.0.host":   "x",
+			"servers.0.tags.0": "web",
+			"servers.1.host":   "y",
+			"extra":            map[string]interface{}{},
+			"list":             []interface{}{},
+			"nil":              nil,
+		}},
+		{"bracket", FlattenOptions{IndexStyle: IndexBracket}, map[string]interface{}{
+			"name":               "app",
+			"db.host":            "localhost",
+			"db.port":            5432,
+			"db.hosts[0]":        "a",
+			"db.hosts[1]":        "b",
+			"servers[0].host":    "x",
+			"servers[0].tags[0]": "web",
+			"servers[1].host":    "y",
+			"extra":              map[string]interface{}{},
+			"list":               []interface{}{},
+			"nil":                nil,
+		}},
+		{"separator", FlattenOptions{Separator: "__"}, map[string]interface{}{
+			"name":                "app",
+			"db__host":            "localhost",
+			"db__port":            5432,
+			"db__hosts__0":        "a",
+			"db__hosts__1":        "b",
+			"servers__0__host":    "x",
+			"servers__0__tags__0": "web",
+			"servers__1__host":    "y",
+			"extra":               map[string]interface{}{},
+			"list":                []interface{}{},
+			"nil":                 nil,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Flatten(flattenTestMap(), tt.opts)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %#v, got %#v", tt.want, got)
+			}
+			back, err := Unflatten(got, tt.opts)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(back, flattenTestMap()) {
+				t.Fatalf("round trip: expected %#v, got %#v", flattenTestMap(), back)
+			}
+		})
+	}
+}
+
+func TestFlattenTyped(t *testing.T) {
+	m := map[string]interface{}{
+		"labels": map[string]string{"env": "prod"},
+		"ports":  []int{80, 443},
+		"raw":    []byte("x"),
+	}
+	got, err := Flatten(m, FlattenOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := map[string]interface{}{"labels.env": "prod", "ports.0": 80, "ports.1": 443, "raw": []byte("x")}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+}
+
+func TestFlattenCollision(t *testing.T) {
+	m := map[string]interface{}{
+		"a.b": 1,
+		"a":   map[string]interface{}{"b": 2},
+	}
+	if _, err := Flatten(m, FlattenOptions{}); !errors.Is(err, ErrFlattenCollision) {
+		t.Fatalf("expected ErrFlattenCollision, got %v", err)
+	}
+	if _, err := Flatten(m, FlattenOptions{Separator: "_"}); err != nil {
+		t.Fatalf("expected no error with another separator, got %v", err)
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	got, err := Unflatten(map[string]interface{}{
+		"a_b_c":    1,
+		"a_d":      "x",
+		"list[0]":  true,
+		`weird[x]`: "literal",
+	}, FlattenOptions{Separator: "_", IndexStyle: IndexBracket})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := map[string]interface{}{
+		"a":        map[string]interface{}{"b": map[string]interface{}{"c": 1}, "d": "x"},
+		"list":     []interface{}{true},
+		"weird[x]": "literal",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+
+	collisions := []map[string]interface{}{
+		{"a": 1, "a.b": 2},
+		{"a.b": 1, "a.0": 2},
+		{"a.0": 1, "a.00": 2},
+		{"a": map[string]interface{}{"b": 1}, "a.b": 2},
+		{"a": nil, "a.b": 1},
+		{"a.0": nil, "a.0.b": 1},
+		{"a": map[string]interface{}(nil), "a.b": 1},
+		{"a": []interface{}(nil), "a.0": 1},
+	}
+	for _, flat := range collisions {
+		if _, err := Unflatten(flat, FlattenOptions{}); !errors.Is(err, ErrFlattenCollision) {
+			t.Fatalf("%v: expected ErrFlattenCollision, got %v", flat, err)
+		}
+	}
+	if _, err := Unflatten(map[string]interface{}{"a[0]": 1, "a.b": 2}, FlattenOptions{IndexStyle: IndexBracket}); !errors.Is(err, ErrFlattenCollision) {
+		t.Fatalf("expected ErrFlattenCollision, got %v", err)
+	}
+}
+
+func TestUnflattenIndexes(t *testing.T) {
+	m := map[string]interface{}{"list": []interface{}{}}
+	for i := 0; i < 12; i++ {
+		m["list"] = append(m["list"].([]interface{}), i)
+	}
+	flat, err := Flatten(m, FlattenOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	back, err := Unflatten(flat, FlattenOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(back, m) {
+		t.Fatalf("expected indexes sorted by value, got %#v", back)
+	}
+
+	gaps := []map[string]interface{}{
+		{"ports.8080": "http"},
+		{"ports.4000000000": "x"},
+		{"list.0": 1, "list.2": 3},
+	}
+	for _, flat := range gaps {
+		if _, err := Unflatten(flat, FlattenOptions{}); !errors.Is(err, ErrInvalidPath) {
+			t.Fatalf("%v: expected ErrInvalidPath, got %v", flat, err)
+		}
+	}
+	if _, err := Unflatten(map[string]interface{}{"list[1]": 1}, FlattenOptions{IndexStyle: IndexBracket}); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("expected ErrInvalidPath, got %v", err)
+	}
+}
@@ -82,7 +82,8 @@ func StructToMap(obj interface{}) map[string]interface{} {
 	return m
 }
 
-// StructToMapE convert struct to map with error handling
+// StructToMapE convert struct to map with error handling, it goes through JSON so numbers become float64,
+// use StructToMapWith to keep Go types
 func StructToMapE(obj interface{}) (map[string]interface{}, error) {
 	jsonData, err := sonic.Marshal(obj)
 	if err != nil {
@@ -104,7 +105,8 @@ func MapToStruct[T any](m map[string]interface{}) T {
 	return out
 }
 
-// MapToStructE convert map to struct with error handling
+// MapToStructE convert map to struct with error handling, it goes through JSON,
+// use MapToStructWith for weak typing and field paths in errors
 func MapToStructE[T any](m map[string]interface{}) (out T, err error) {
 	jsonData, err := sonic.Marshal(m)
 	if err != nil {
@@ -0,0 +1,219 @@
+package maputil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrMergeConflict is returned by ConflictError when two layers set different values for a key
+var ErrMergeConflict = errors.New("maputil: merge conflict")
+
+// SliceStrategy is how DeepMerge combine two slices found at the same key
+type SliceStrategy int
+
+// slice merge strategies
+const (
+	// SliceReplace use the slice of the later layer
+	SliceReplace SliceStrategy = iota
+	// SliceAppend append the later slice to the earlier one
+	SliceAppend
+	// SliceUnion append the elements not already present, maps with the same MergeOptions.UnionKey value are merged
+	SliceUnion
+)
+
+// ConflictStrategy is how DeepMerge resolve a key set by two layers when the values are not both maps or slices
+type ConflictStrategy int
+
+// conflict strategies
+const (
+	// ConflictOverride use the value of the later layer
+	ConflictOverride ConflictStrategy = iota
+	// ConflictKeep keep the value of the earlier layer
+	ConflictKeep
+	// ConflictError fail with ErrMergeConflict if the values differ
+	ConflictError
+)
+
+// MergeOptions control how DeepMerge combine layers, the zero value override scalars and replace slices
+type MergeOptions struct {
+	Slices SliceStrategy
+	// UnionKey identify map elements of slices merged with SliceUnion, exp: "name"
+	UnionKey string
+	Conflict ConflictStrategy
+}
+
+// DeepMerge merge layers into a new map, nested map[string]interface{} are merged recursively,
+// later layers take precedence according to opts, the layers are not modified
+// exp: DeepMerge(MergeOptions{}, defaults, fileConfig, envConfig)
+func DeepMerge(opts MergeOptions, layers ...map[string]interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	if err := DeepMergeInto(out, opts, layers...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeepMergeInto merge layers into dst like DeepMerge, values taken from layers are copied
+// so dst never share maps or slices with them
+func DeepMergeInto(dst map[string]interface{}, opts MergeOptions, layers ...map[string]interface{}) error {
+	for _, layer := range layers {
+		if err := mergeMap("", dst, layer, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeepCopyMap copy m with its nested maps and slices
+func DeepCopyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = deepCopy(v)
+	}
+	return out
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return DeepCopyMap(val)
+	case []interface{}:
+		if val == nil {
+			return val
+		}
+		out := make([]interface{}, len(val))
+		for i, e := range val {
+			out[i] = deepCopy(e)
+		}
+		return out
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && !rv.IsNil() {
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		reflect.Copy(out, rv)
+		for i := 0; i < out.Len(); i++ {
+			// elements of typed slices like []map[string]interface{}
+			if e := out.Index(i); (e.Kind() == reflect.Map || e.Kind() == reflect.Slice) && !e.IsNil() {
+				e.Set(reflect.ValueOf(deepCopy(e.Interface())))
+			}
+		}
+		return out.Interface()
+	}
+	return v
+}
+
+func mergeMap(path string, dst, src map[string]interface{}, opts MergeOptions) error {
+	for k, sv := range src {
+		keyPath := joinPath(path, k)
+		dv, ok := dst[k]
+		if !ok {
+			dst[k] = deepCopy(sv)
+			continue
+		}
+		merged, err := mergeValue(keyPath, dv, sv, opts)
+		if err != nil {
+			return err
+		}
+		dst[k] = merged
+	}
+	return nil
+}
+
+func mergeValue(path string, dv, sv interface{}, opts MergeOptions) (interface{}, error) {
+	dm, dIsMap := dv.(map[string]interface{})
+	sm, sIsMap := sv.(map[string]interface{})
+	if dIsMap && sIsMap {
+		if dm == nil {
+			// a nil map can not be written to, the later layer is copied instead
+			return DeepCopyMap(sm), nil
+		}
+		return dm, mergeMap(path, dm, sm, opts)
+	}
+	ds, ss := reflect.ValueOf(dv), reflect.ValueOf(sv)
+	if ds.Kind() == reflect.Slice && ss.Kind() == reflect.Slice {
+		return mergeSlice(path, ds, ss, opts)
+	}
+	switch opts.Conflict {
+	case ConflictKeep:
+		return dv, nil
+	case ConflictError:
+		if !reflect.DeepEqual(dv, sv) {
+			return nil, fmt.Errorf("%w at %s: %v and %v", ErrMergeConflict, path, dv, sv)
+		}
+		return dv, nil
+	}
+	return deepCopy(sv), nil
+}
+
+func mergeSlice(path string, ds, ss reflect.Value, opts MergeOptions) (interface{}, error) {
+	switch opts.Slices {
+	case SliceAppend:
+		return sameTypeSlice(ds, ss, append(toInterfaces(ds), toInterfaces(ss)...)), nil
+	case SliceUnion:
+		out := toInterfaces(ds)
+		for i := 0; i < ss.Len(); i++ {
+			e := ss.Index(i).Interface()
+			j := unionIndex(out, e, opts.UnionKey)
+			if j < 0 {
+				out = append(out, deepCopy(e))
+				continue
+			}
+			if em, ok := e.(map[string]interface{}); ok && opts.UnionKey != "" {
+				merged, err := mergeValue(fmt.Sprintf("%s[%d]", path, j), out[j], em, opts)
+				if err != nil {
+					return nil, err
+				}
+				out[j] = merged
+			}
+		}
+		return sameTypeSlice(ds, ss, out), nil
+	}
+	return deepCopy(ss.Interface()), nil
+}
+
+// unionIndex find the element of list equal to e, or the map element with the same key value
+func unionIndex(list []interface{}, e interface{}, key string) int {
+	em, isMap := e.(map[string]interface{})
+	for i, v := range list {
+		if isMap && key != "" {
+			if vm, ok := v.(map[string]interface{}); ok {
+				if kv, ok := em[key]; ok && reflect.DeepEqual(vm[key], kv) {
+					return i
+				}
+				continue
+			}
+		}
+		if reflect.DeepEqual(v, e) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameTypeSlice return elems as a slice of the type of a and b when they have the same type,
+// exp: []string, and as []interface{} otherwise
+func sameTypeSlice(a, b reflect.Value, elems []interface{}) interface{} {
+	t := a.Type()
+	if t != b.Type() || t == reflect.TypeOf(elems) {
+		return elems
+	}
+	out := reflect.MakeSlice(t, len(elems), len(elems))
+	for i, e := range elems {
+		if e != nil {
+			out.Index(i).Set(reflect.ValueOf(e))
+		}
+	}
+	return out.Interface()
+}
+
+func toInterfaces(v reflect.Value) []interface{} {
+	out := make([]interface{}, v.Len())
+	for i := range out {
+		out[i] = deepCopy(v.Index(i).Interface())
+	}
+	return out
+}
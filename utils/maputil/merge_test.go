@@ -0,0 +1,205 @@
+package maputil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDeepMerge(t *testing.T) {
+	defaults := map[string]interface{}{
+		"name": "app",
+		"server": map[string]interface{}{
+			"host": "localhost",
+			"port": 8080,
+			"tls":  map[string]interface{}{"enabled": false},
+		},
+		"tags": []interface{}{"a"},
+	}
+	file := map[string]interface{}{
+		"server": map[string]interface{}{
+			"port": 9090,
+			"tls":  map[string]interface{}{"cert": "/etc/cert.pem"},
+		},
+		"tags": []interface{}{"b"},
+	}
+	got, err := DeepMerge(MergeOptions{}, defaults, file)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := map[string]interface{}{
+		"name": "app",
+		"server": map[string]interface{}{
+			"host": "localhost",
+			"port": 9090,
+			"tls":  map[string]interface{}{"enabled": false, "cert": "/etc/cert.pem"},
+		},
+		"tags": []interface{}{"b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+
+	// inputs are untouched and not shared
+	if defaults["server"].(map[string]interface{})["port"] != 8080 || len(file) != 2 {
+		t.Fatalf("inputs modified: %#v %#v", defaults, file)
+	}
+	got["server"].(map[string]interface{})["host"] = "changed"
+	if defaults["server"].(map[string]interface{})["host"] != "localhost" {
+		t.Fatalf("result shares maps with inputs")
+	}
+}
+
+func TestDeepMergeSlices(t *testing.T) {
+	a := map[string]interface{}{
+		"tags":  []string{"x", "y"},
+		"ports": []interface{}{80, 443},
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice", "role": "dev"},
+			map[string]interface{}{"name": "bob", "role": "dev"},
+		},
+	}
+	b := map[string]interface{}{
+		"tags":  []string{"y", "z"},
+		"ports": []interface{}{443, 8443},
+		"users": []interface{}{
+			map[string]interface{}{"name": "bob", "role": "admin"},
+			map[string]interface{}{"name": "carol"},
+		},
+	}
+	tests := []struct {
+		name string
+		opts MergeOptions
+		want map[string]interface{}
+	}{
+		{
+			name: "replace",
+			opts: MergeOptions{Slices: SliceReplace},
+			want: map[string]interface{}{"tags": []string{"y", "z"}, "ports": []interface{}{443, 8443}, "users": b["users"]},
+		},
+		{
+			name: "append",
+			opts: MergeOptions{Slices: SliceAppend},
+			want: map[string]interface{}{
+				"tags":  []string{"x", "y", "y", "z"},
+				"ports": []interface{}{80, 443, 443, 8443},
+				"users": append(append([]interface{}{}, a["users"].([]interface{})...), b["users"].([]interface{})...),
+			},
+		},
+		{
+			name: "unionByKey",
+			opts: MergeOptions{Slices: SliceUnion, UnionKey: "name"},
+			want: map[string]interface{}{
+				"tags":  []string{"x", "y", "z"},
+				"ports": []interface{}{80, 443, 8443},
+				"users": []interface{}{
+					map[string]interface{}{"name": "alice", "role": "dev"},
+					map[string]interface{}{"name": "bob", "role": "admin"},
+					map[string]interface{}{"name": "carol"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeepMerge(tt.opts, a, b)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+	if a["users"].([]interface{})[1].(map[string]interface{})["role"] != "dev" {
+		t.Fatalf("union modified the input")
+	}
+}
+
+func TestDeepMergeConflicts(t *testing.T) {
+	a := map[string]interface{}{"port": 80, "db": map[string]interface{}{"host": "a"}, "same": "x"}
+	b := map[string]interface{}{"port": 8080, "db": map[string]interface{}{"host": "b"}, "same": "x"}
+
+	got, err := DeepMerge(MergeOptions{Conflict: ConflictKeep}, a, b)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got["port"] != 80 || got["db"].(map[string]interface{})["host"] != "a" {
+		t.Fatalf("expected earlier values kept, got %#v", got)
+	}
+
+	_, err = DeepMerge(MergeOptions{Conflict: ConflictError}, a, b)
+	if !errors.Is(err, ErrMergeConflict) {
+		t.Fatalf("expected ErrMergeConflict, got %v", err)
+	}
+	if _, err := DeepMerge(MergeOptions{Conflict: ConflictError}, a, map[string]interface{}{"same": "x"}); err != nil {
+		t.Fatalf("expected equal values to merge, got %v", err)
+	}
+
+	// a map replaced by a scalar is a conflict too
+	got, err = DeepMerge(MergeOptions{}, a, map[string]interface{}{"db": "sqlite"})
+	if err != nil || got["db"] != "sqlite" {
+		t.Fatalf("expected scalar override, got %#v, %v", got, err)
+	}
+}
+
+func TestDeepMergeNilMap(t *testing.T) {
+	src := map[string]interface{}{"b": 1}
+	got, err := DeepMerge(MergeOptions{}, map[string]interface{}{"a": map[string]interface{}(nil)}, map[string]interface{}{"a": src})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := map[string]interface{}{"a": map[string]interface{}{"b": 1}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %#v, got %#v", want, got)
+	}
+	got["a"].(map[string]interface{})["b"] = 2
+	if src["b"] != 1 {
+		t.Fatalf("result shares maps with inputs")
+	}
+
+	got, err = DeepMerge(MergeOptions{}, map[string]interface{}{"a": src}, map[string]interface{}{"a": map[string]interface{}(nil)})
+	if err != nil || !reflect.DeepEqual(got["a"], src) {
+		t.Fatalf("expected nil map merged as empty, got %#v, %v", got, err)
+	}
+}
+
+func TestDeepMergeInto(t *testing.T) {
+	dst := map[string]interface{}{"a": map[string]interface{}{"b": 1}}
+	layer := map[string]interface{}{"a": map[string]interface{}{"c": []interface{}{1}}}
+	if err := DeepMergeInto(dst, MergeOptions{}, layer); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := map[string]interface{}{"a": map[string]interface{}{"b": 1, "c": []interface{}{1}}}
+	if !reflect.DeepEqual(dst, want) {
+		t.Fatalf("expected %#v, got %#v", want, dst)
+	}
+	dst["a"].(map[string]interface{})["c"].([]interface{})[0] = 2
+	if layer["a"].(map[string]interface{})["c"].([]interface{})[0] != 1 {
+		t.Fatalf("dst shares slices with the layer")
+	}
+}
+
+func TestDeepCopyMap(t *testing.T) {
+	src := map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{map[string]interface{}{"c": 1}}}, "s": []string{"x"}}
+	cp := DeepCopyMap(src)
+	if !reflect.DeepEqual(src, cp) {
+		t.Fatalf("expected equal copy, got %#v", cp)
+	}
+	cp["a"].(map[string]interface{})["b"].([]interface{})[0].(map[string]interface{})["c"] = 2
+	cp["s"].([]string)[0] = "y"
+	if src["a"].(map[string]interface{})["b"].([]interface{})[0].(map[string]interface{})["c"] != 1 || src["s"].([]string)[0] != "x" {
+		t.Fatalf("copy shares data with source")
+	}
+	typed := map[string]interface{}{"list": []map[string]interface{}{{"a": 1}}, "errs": []error{nil}}
+	typedCopy := DeepCopyMap(typed)
+	typedCopy["list"].([]map[string]interface{})[0]["a"] = 2
+	if typed["list"].([]map[string]interface{})[0]["a"] != 1 {
+		t.Fatalf("copy shares maps of typed slices")
+	}
+	if _, err := DeepMerge(MergeOptions{Slices: SliceAppend}, typed, typed); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if DeepCopyMap(nil) != nil {
+		t.Fatalf("expected nil copy of nil map")
+	}
+}
@@ -0,0 +1,330 @@
+package maputil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidPath is returned for a malformed path, or by SetPath for an index past the end of a slice
+	ErrInvalidPath = errors.New("maputil: invalid path")
+	// ErrPathType is returned by SetPath when a value on the path is not a map[string]interface{} or a []interface{}
+	ErrPathType = errors.New("maputil: path crosses a value that is not a map or a slice")
+)
+
+// weakDecoder convert the values of the typed getters
+var weakDecoder = NewDecoder(DecoderConfig{WeaklyTyped: true})
+
+// pathSegment is a map key or a slice index of a path
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath split "a.b[2].c" into segments, a backslash escape ".", "[", "]" and itself in keys
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: empty path", ErrInvalidPath)
+	}
+	var segs []pathSegment
+	var key strings.Builder
+	hasKey := false
+	// afterIndex is set after "]", only ".", "[" or the end may follow
+	afterIndex := false
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if afterIndex && c != '.' && c != '[' {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		switch c {
+		case '\\':
+			if i+1 == len(path) {
+				return nil, fmt.Errorf("%w: trailing backslash in %q", ErrInvalidPath, path)
+			}
+			i++
+			key.WriteByte(path[i])
+			hasKey = true
+		case '.':
+			if !hasKey && !afterIndex {
+				return nil, fmt.Errorf("%w: empty key in %q", ErrInvalidPath, path)
+			}
+			if hasKey {
+				segs = append(segs, pathSegment{key: key.String()})
+				key.Reset()
+				hasKey = false
+			}
+			afterIndex = false
+			if i+1 == len(path) {
+				return nil, fmt.Errorf("%w: empty key in %q", ErrInvalidPath, path)
+			}
+		case '[':
+			if hasKey {
+				segs = append(segs, pathSegment{key: key.String()})
+				key.Reset()
+				hasKey = false
+			}
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unclosed \"[\" in %q", ErrInvalidPath, path)
+			}
+			n, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%w: bad index %q in %q", ErrInvalidPath, path[i+1:i+end], path)
+			}
+			segs = append(segs, pathSegment{index: n, isIndex: true})
+			i += end
+			afterIndex = true
+		case ']':
+			return nil, fmt.Errorf("%w: unexpected \"]\" in %q", ErrInvalidPath, path)
+		default:
+			key.WriteByte(c)
+			hasKey = true
+		}
+	}
+	if hasKey {
+		segs = append(segs, pathSegment{key: key.String()})
+	}
+	return segs, nil
+}
+
+// EscapePathKey escape the characters of key having a meaning in paths
+// exp: EscapePathKey("example.com") -> "example\.com"
+func EscapePathKey(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '.', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(key[i])
+	}
+	return b.String()
+}
+
+// GetPath get the value at path in m, path is made of dot separated keys and [i] slice indexes,
+// exp: GetPath(m, "servers[0].host"), GetPath(m, `hosts.example\.com`)
+func GetPath(m map[string]interface{}, path string) (interface{}, bool) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+	return getIn(m, segs)
+}
+
+// HasPath check if a value, even nil, exists at path in m
+func HasPath(m map[string]interface{}, path string) bool {
+	_, ok := GetPath(m, path)
+	return ok
+}
+
+// SetPath set value at path in m, missing maps and slices on the path are created and an index equal
+// to the length of a slice append to it, a larger index fails with ErrInvalidPath,
+// it fails with ErrPathType if the path crosses a scalar or a typed map or slice
+// exp: SetPath(m, "server.tls.enabled", true), SetPath(m, "servers[0].host", "a")
+func SetPath(m map[string]interface{}, path string, value interface{}) error {
+	if m == nil {
+		return fmt.Errorf("%w: nil map", ErrPathType)
+	}
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	_, err = setIn(m, segs, value, "")
+	return err
+}
+
+// DeletePath delete the value at path in m, a slice element is removed and the following ones shifted,
+// it return false if there is no value at path or, like SetPath, if the path crosses a typed map or slice
+func DeletePath(m map[string]interface{}, path string) bool {
+	segs, err := parsePath(path)
+	if err != nil {
+		return false
+	}
+	_, ok := deleteIn(m, segs)
+	return ok
+}
+
+// GetPathAs get the value at path in m converted to T with weak typing, def is returned
+// if the value is missing, nil or can not be converted
+// exp: GetPathAs(m, "server.port", 8080)
+func GetPathAs[T any](m map[string]interface{}, path string, def T) T {
+	v, ok := GetPath(m, path)
+	if !ok || v == nil {
+		return def
+	}
+	if t, ok := v.(T); ok {
+		return t
+	}
+	var out T
+	if err := weakDecoder.decode(path, v, reflect.ValueOf(&out).Elem()); err != nil {
+		return def
+	}
+	return out
+}
+
+// GetString get the string at path in m, numbers and bools are formatted
+func GetString(m map[string]interface{}, path string, def string) string {
+	return GetPathAs(m, path, def)
+}
+
+// GetInt get the int at path in m, strings and floats holding integers are converted
+func GetInt(m map[string]interface{}, path string, def int) int {
+	return GetPathAs(m, path, def)
+}
+
+// GetInt64 get the int64 at path in m
+func GetInt64(m map[string]interface{}, path string, def int64) int64 {
+	return GetPathAs(m, path, def)
+}
+
+// GetFloat64 get the float64 at path in m
+func GetFloat64(m map[string]interface{}, path string, def float64) float64 {
+	return GetPathAs(m, path, def)
+}
+
+// GetBool get the bool at path in m, exp: true, "true", "1" or 1
+func GetBool(m map[string]interface{}, path string, def bool) bool {
+	return GetPathAs(m, path, def)
+}
+
+// GetDuration get the duration at path in m, exp: "1m30s" or a number of nanoseconds
+func GetDuration(m map[string]interface{}, path string, def time.Duration) time.Duration {
+	return GetPathAs(m, path, def)
+}
+
+// GetStringSlice get the []string at path in m, a single value is returned as one element slice
+func GetStringSlice(m map[string]interface{}, path string, def []string) []string {
+	return GetPathAs(m, path, def)
+}
+
+// GetMap get the map at path in m
+func GetMap(m map[string]interface{}, path string, def map[string]interface{}) map[string]interface{} {
+	return GetPathAs(m, path, def)
+}
+
+// getIn return the value at segs under cur
+func getIn(cur interface{}, segs []pathSegment) (interface{}, bool) {
+	for _, seg := range segs {
+		next, ok := child(cur, seg)
+		if !ok {
+			return nil, false
+		}
+		cur = next
+	}
+	return cur, true
+}
+
+// child return the value of a segment in cur, maps with string keys and slices are supported
+func child(cur interface{}, seg pathSegment) (interface{}, bool) {
+	if seg.isIndex {
+		if s, ok := cur.([]interface{}); ok {
+			if seg.index >= len(s) {
+				return nil, false
+			}
+			return s[seg.index], true
+		}
+		rv := reflect.ValueOf(cur)
+		if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || seg.index >= rv.Len() {
+			return nil, false
+		}
+		return rv.Index(seg.index).Interface(), true
+	}
+	if mm, ok := cur.(map[string]interface{}); ok {
+		v, ok := mm[seg.key]
+		return v, ok
+	}
+	rv := reflect.ValueOf(cur)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	v := rv.MapIndex(reflect.ValueOf(seg.key).Convert(rv.Type().Key()))
+	if !v.IsValid() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// setIn set value under cur and return cur, or the container replacing it when it was created or grown
+func setIn(cur interface{}, segs []pathSegment, value interface{}, path string) (interface{}, error) {
+	if len(segs) == 0 {
+		return value, nil
+	}
+	seg, rest := segs[0], segs[1:]
+	if seg.isIndex {
+		path = fmt.Sprintf("%s[%d]", path, seg.index)
+		if cur == nil {
+			cur = []interface{}{}
+		}
+		s, ok := cur.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is %T", ErrPathType, path, cur)
+		}
+		if seg.index > len(s) {
+			return nil, fmt.Errorf("%w: index %s is past the end of a slice of %d elements", ErrInvalidPath, path, len(s))
+		}
+		if seg.index == len(s) {
+			s = append(s, nil)
+		}
+		v, err := setIn(s[seg.index], rest, value, path)
+		if err != nil {
+			return nil, err
+		}
+		s[seg.index] = v
+		return s, nil
+	}
+	path = joinPath(path, EscapePathKey(seg.key))
+	if cur == nil {
+		cur = make(map[string]interface{})
+	}
+	mm, ok := cur.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is %T", ErrPathType, path, cur)
+	}
+	if mm == nil {
+		// a nil map is like a missing one, the new map is set in the parent by the caller
+		mm = make(map[string]interface{})
+	}
+	v, err := setIn(mm[seg.key], rest, value, path)
+	if err != nil {
+		return nil, err
+	}
+	mm[seg.key] = v
+	return mm, nil
+}
+
+// deleteIn delete the value at segs under cur and return cur, or the shortened slice replacing it
+func deleteIn(cur interface{}, segs []pathSegment) (interface{}, bool) {
+	seg, rest := segs[0], segs[1:]
+	if seg.isIndex {
+		s, ok := cur.([]interface{})
+		if !ok || seg.index >= len(s) {
+			return cur, false
+		}
+		if len(rest) == 0 {
+			return append(s[:seg.index:seg.index], s[seg.index+1:]...), true
+		}
+		v, ok := deleteIn(s[seg.index], rest)
+		s[seg.index] = v
+		return s, ok
+	}
+	mm, ok := cur.(map[string]interface{})
+	if !ok {
+		return cur, false
+	}
+	v, exists := mm[seg.key]
+	if !exists {
+		return cur, false
+	}
+	if len(rest) == 0 {
+		delete(mm, seg.key)
+		return mm, true
+	}
+	v, ok = deleteIn(v, rest)
+	mm[seg.key] = v
+	return mm, ok
+}
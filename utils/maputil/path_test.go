@@ -0,0 +1,241 @@
+package maputil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func pathTestMap() map[string]interface{} {
+	return map[string]interface{}{
+		"server": map[string]interface{}{
+			"host":    "localhost",
+			"port":    8080,
+			"timeout": "1m30s",
+			"debug":   "true",
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a", "ports": []int{80, 443}},
+			map[string]interface{}{"host": "b"},
+		},
+		"hosts": map[string]interface{}{
+			"example.com": map[string]interface{}{"ip": "10.0.0.1"},
+		},
+		"labels": map[string]string{"env": "prod"},
+		"empty":  nil,
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []pathSegment
+	}{
+		{"key", "a", []pathSegment{{key: "a"}}},
+		{"nested", "a.b.c", []pathSegment{{key: "a"}, {key: "b"}, {key: "c"}}},
+		{"index", "a[2].c", []pathSegment{{key: "a"}, {index: 2, isIndex: true}, {key: "c"}}},
+		{"nested index", "a[0][1]", []pathSegment{{key: "a"}, {isIndex: true}, {index: 1, isIndex: true}}},
+		{"root index", "[1]", []pathSegment{{index: 1, isIndex: true}}},
+		{"escaped dot", `a\.b.c`, []pathSegment{{key: "a.b"}, {key: "c"}}},
+		{"escaped brackets", `a\[0\]`, []pathSegment{{key: "a[0]"}}},
+		{"escaped backslash", `a\\.b`, []pathSegment{{key: `a\`}, {key: "b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePath(tt.in)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	for _, in := range []string{"", ".a", "a.", "a..b", "a[", "a[x]", "a[-1]", "a]", "a[0]b", `a\`} {
+		if _, err := parsePath(in); !errors.Is(err, ErrInvalidPath) {
+			t.Fatalf("%q: expected ErrInvalidPath, got %v", in, err)
+		}
+	}
+}
+
+func TestEscapePathKey(t *testing.T) {
+	if got := EscapePathKey(`a.b[0]\c`); got != `a\.b\[0\]\\c` {
+		t.Fatalf("unexpected escape: %q", got)
+	}
+	key := "we.ird[1]\\"
+	got, err := parsePath(EscapePathKey(key))
+	if err != nil || len(got) != 1 || got[0].key != key {
+		t.Fatalf("escaped key does not round trip: %+v %v", got, err)
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	m := pathTestMap()
+	tests := []struct {
+		name string
+		in   string
+		want interface{}
+		ok   bool
+	}{
+		{"nested", "server.host", "localhost", true},
+		{"slice", "servers[1].host", "b", true},
+		{"typed slice", "servers[0].ports[1]", 443, true},
+		{"escaped key", `hosts.example\.com.ip`, "10.0.0.1", true},
+		{"typed map", "labels.env", "prod", true},
+		{"nil value", "empty", nil, true},
+		{"missing", "server.missing", nil, false},
+		{"out of range", "servers[2]", nil, false},
+		{"index on map", "server[0]", nil, false},
+		{"key on scalar", "server.host.x", nil, false},
+		{"invalid", "server..host", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetPath(m, tt.in)
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v %v, got %v %v", tt.want, tt.ok, got, ok)
+			}
+			if HasPath(m, tt.in) != tt.ok {
+				t.Fatalf("HasPath does not match GetPath")
+			}
+		})
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	m := pathTestMap()
+	sets := []struct {
+		path  string
+		value interface{}
+	}{
+		{"server.port", 9090},
+		{"server.tls.enabled", true},
+		{"servers[1].host", "c"},
+		{"servers[2].host", "d"},
+		{"new[0][0]", "x"},
+		{"new[1]", "y"},
+		{`hosts.example\.org`, "new"},
+	}
+	for _, s := range sets {
+		if err := SetPath(m, s.path, s.value); err != nil {
+			t.Fatalf("%s: expected no error, got %v", s.path, err)
+		}
+		if got, _ := GetPath(m, s.path); !reflect.DeepEqual(got, s.value) {
+			t.Fatalf("%s: expected %v, got %v", s.path, s.value, got)
+		}
+	}
+	if servers := m["servers"].([]interface{}); len(servers) != 3 {
+		t.Fatalf("unexpected servers: %#v", servers)
+	}
+	want := []interface{}{[]interface{}{"x"}, "y"}
+	if !reflect.DeepEqual(m["new"], want) {
+		t.Fatalf("expected %#v, got %#v", want, m["new"])
+	}
+	if _, ok := m["hosts"].(map[string]interface{})["example.org"]; !ok {
+		t.Fatalf("escaped key not set: %#v", m["hosts"])
+	}
+
+	errTests := []struct {
+		path string
+		err  error
+	}{
+		{"server.host.x", ErrPathType},
+		{"server[0]", ErrPathType},
+		{"labels.env", ErrPathType},
+		{"server.", ErrInvalidPath},
+		{"servers[4]", ErrInvalidPath},
+		{"servers[1000000000].host", ErrInvalidPath},
+		{"other[1]", ErrInvalidPath},
+	}
+	for _, tt := range errTests {
+		if err := SetPath(m, tt.path, 1); !errors.Is(err, tt.err) {
+			t.Fatalf("%s: expected %v, got %v", tt.path, tt.err, err)
+		}
+	}
+	nested := map[string]interface{}{"a": map[string]interface{}(nil), "s": []interface{}(nil)}
+	if err := SetPath(nested, "a.b", 1); err != nil || GetInt(nested, "a.b", 0) != 1 {
+		t.Fatalf("expected nil map replaced, got %#v, %v", nested, err)
+	}
+	if err := SetPath(nested, "s[0].c", 2); err != nil || GetInt(nested, "s[0].c", 0) != 2 {
+		t.Fatalf("expected nil slice appended to, got %#v, %v", nested, err)
+	}
+	if err := SetPath(nil, "a", 1); !errors.Is(err, ErrPathType) {
+		t.Fatalf("expected ErrPathType for nil map, got %v", err)
+	}
+}
+
+func TestDeletePath(t *testing.T) {
+	m := pathTestMap()
+	if !DeletePath(m, "server.port") || HasPath(m, "server.port") {
+		t.Fatalf("server.port not deleted")
+	}
+	if !DeletePath(m, "servers[0]") {
+		t.Fatalf("servers[0] not deleted")
+	}
+	if got := GetString(m, "servers[0].host", ""); got != "b" || len(m["servers"].([]interface{})) != 1 {
+		t.Fatalf("slice not shifted: %#v", m["servers"])
+	}
+	if !DeletePath(m, `hosts.example\.com.ip`) || len(m["hosts"].(map[string]interface{})["example.com"].(map[string]interface{})) != 0 {
+		t.Fatalf("escaped key not deleted: %#v", m["hosts"])
+	}
+	if !DeletePath(m, "empty") || HasPath(m, "empty") {
+		t.Fatalf("nil value not deleted")
+	}
+	for _, path := range []string{"server.port", "servers[5]", "server.host.x", "missing.a", "a..b"} {
+		if DeletePath(m, path) {
+			t.Fatalf("%s: expected nothing deleted", path)
+		}
+	}
+
+	// typed maps and slices are read only
+	m = pathTestMap()
+	if DeletePath(m, "labels.env") || DeletePath(m, "servers[0].ports[0]") || !HasPath(m, "labels.env") {
+		t.Fatalf("expected typed containers left untouched")
+	}
+}
+
+func TestGetTyped(t *testing.T) {
+	m := pathTestMap()
+	if got := GetString(m, "server.port", ""); got != "8080" {
+		t.Fatalf("unexpected string: %q", got)
+	}
+	if got := GetString(m, "server.missing", "def"); got != "def" {
+		t.Fatalf("expected default, got %q", got)
+	}
+	if got := GetString(m, "empty", "def"); got != "def" {
+		t.Fatalf("expected default for nil, got %q", got)
+	}
+	if got := GetInt(m, "server.port", 0); got != 8080 {
+		t.Fatalf("unexpected int: %d", got)
+	}
+	if got := GetInt(m, "server.host", 1); got != 1 {
+		t.Fatalf("expected default for bad int, got %d", got)
+	}
+	if got := GetInt64(m, "servers[0].ports[0]", 0); got != 80 {
+		t.Fatalf("unexpected int64: %d", got)
+	}
+	if got := GetFloat64(m, "server.port", 0); got != 8080 {
+		t.Fatalf("unexpected float64: %v", got)
+	}
+	if got := GetBool(m, "server.debug", false); !got {
+		t.Fatalf("expected true")
+	}
+	if got := GetDuration(m, "server.timeout", 0); got != 90*time.Second {
+		t.Fatalf("unexpected duration: %v", got)
+	}
+	if got := GetDuration(m, "server.missing", time.Second); got != time.Second {
+		t.Fatalf("expected default duration, got %v", got)
+	}
+	if got := GetStringSlice(m, "servers[0].ports", nil); !reflect.DeepEqual(got, []string{"80", "443"}) {
+		t.Fatalf("unexpected slice: %#v", got)
+	}
+	if got := GetMap(m, "servers[1]", nil); got["host"] != "b" {
+		t.Fatalf("unexpected map: %#v", got)
+	}
+	if got := GetPathAs(m, "labels", map[string]string{}); got["env"] != "prod" {
+		t.Fatalf("unexpected typed map: %#v", got)
+	}
+}
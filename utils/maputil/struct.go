@@ -0,0 +1,332 @@
+package maputil
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTagName is the struct tag read when no tag name is set
+const DefaultTagName = "json"
+
+var (
+	// ErrNotStruct is returned when a struct or a pointer to a struct is expected
+	ErrNotStruct = errors.New("maputil: not a struct")
+	// ErrCycle is returned by recursive conversion when a pointer, map or slice contains itself
+	ErrCycle = errors.New("maputil: encountered a cycle")
+)
+
+// StructMapOptions control how StructToMapWith convert a struct
+type StructMapOptions struct {
+	// TagName is the struct tag giving field names and options, DefaultTagName if empty, exp: "mapstructure"
+	TagName string
+	// Recursive convert nested structs, also inside pointers, slices and maps, to map[string]interface{},
+	// time.Time and types implementing json.Marshaler or encoding.TextMarshaler are kept as is,
+	// a value containing itself fails with ErrCycle
+	Recursive bool
+}
+
+// StructToMapWith convert a struct to map with reflection, values keep their Go types,
+// tags support a name, "-", "omitempty", "omitzero" and "inline" or "squash" to flatten a field,
+// embedded structs without a tag name are flattened like encoding/json does
+// exp: StructToMapWith(User{ID: 1}, StructMapOptions{}) -> map[string]interface{}{"id": 1}
+func StructToMapWith(obj interface{}, opts StructMapOptions) (map[string]interface{}, error) {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, fmt.Errorf("%w: nil %s", ErrNotStruct, v.Type())
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T", ErrNotStruct, obj)
+	}
+	c := &mapConverter{tag: opts.tagName(), recursive: opts.Recursive}
+	if opts.Recursive {
+		c.visiting = make(map[visitKey]bool)
+	}
+	return c.structToMap(v)
+}
+
+func (o StructMapOptions) tagName() string {
+	if o.TagName == "" {
+		return DefaultTagName
+	}
+	return o.TagName
+}
+
+// fieldPlan describe how a struct field maps to a key
+type fieldPlan struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	omitZero  bool
+}
+
+type planKey struct {
+	t   reflect.Type
+	tag string
+}
+
+// planCache hold the []fieldPlan of each struct type and tag name
+var planCache sync.Map
+
+// structPlan return the cached field plans of struct type t for tag
+func structPlan(t reflect.Type, tag string) []fieldPlan {
+	key := planKey{t, tag}
+	if p, ok := planCache.Load(key); ok {
+		return p.([]fieldPlan)
+	}
+	var plans []fieldPlan
+	seen := make(map[string]bool)
+	buildPlan(t, tag, nil, seen, &plans, map[reflect.Type]bool{})
+	p, _ := planCache.LoadOrStore(key, plans)
+	return p.([]fieldPlan)
+}
+
+// buildPlan add the fields of t to plans, fields of flattened structs come after the outer fields
+// so the shallower field wins a name conflict
+func buildPlan(t reflect.Type, tag string, prefix []int, seen map[string]bool, plans *[]fieldPlan, visiting map[reflect.Type]bool) {
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	type inlineField struct {
+		t     reflect.Type
+		index []int
+	}
+	var inlines []inlineField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		index := append(append([]int{}, prefix...), i)
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		inline := hasTagOption(opts, "inline") || hasTagOption(opts, "squash") || (f.Anonymous && name == "")
+		if inline && ft.Kind() == reflect.Struct {
+			if f.IsExported() || f.Anonymous {
+				inlines = append(inlines, inlineField{ft, index})
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		*plans = append(*plans, fieldPlan{
+			name:      name,
+			index:     index,
+			omitEmpty: hasTagOption(opts, "omitempty"),
+			omitZero:  hasTagOption(opts, "omitzero"),
+		})
+	}
+	for _, in := range inlines {
+		buildPlan(in.t, tag, in.index, seen, plans, visiting)
+	}
+}
+
+func hasTagOption(opts, option string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex return the field at index, ok is false when an embedded pointer on the way is nil
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// structToMap convert struct v to map without converting nested structs
+func structToMap(v reflect.Value, tag string) map[string]interface{} {
+	m, _ := (&mapConverter{tag: tag}).structToMap(v)
+	return m
+}
+
+// visitKey identify a pointer, map or slice being converted, slices also need their length
+// since a slice may contain a shorter slice of the same array, like encoding/json does
+type visitKey struct {
+	ptr uintptr
+	len int
+	t   reflect.Type
+}
+
+// mapConverter convert structs to maps, visiting hold the containers on the current
+// recursion path to detect cycles
+type mapConverter struct {
+	tag       string
+	recursive bool
+	visiting  map[visitKey]bool
+}
+
+func (c *mapConverter) structToMap(v reflect.Value) (map[string]interface{}, error) {
+	plans := structPlan(v.Type(), c.tag)
+	m := make(map[string]interface{}, len(plans))
+	for _, p := range plans {
+		fv, ok := fieldByIndex(v, p.index)
+		if !ok || (p.omitEmpty && isEmptyValue(fv)) || (p.omitZero && fv.IsZero()) {
+			continue
+		}
+		if !c.recursive {
+			m[p.name] = fv.Interface()
+			continue
+		}
+		cv, err := c.convert(fv)
+		if err != nil {
+			return nil, err
+		}
+		m[p.name] = cv
+	}
+	return m, nil
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// keepAsIs report types that recursive conversion must not turn into maps
+func keepAsIs(t reflect.Type) bool {
+	return t == timeType || t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		reflect.PointerTo(t).Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// enter mark the container v as being converted, it fails with ErrCycle if it already is
+func (c *mapConverter) enter(v reflect.Value) (visitKey, error) {
+	key := visitKey{ptr: v.Pointer(), t: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	if c.visiting[key] {
+		return key, fmt.Errorf("%w via %s", ErrCycle, v.Type())
+	}
+	c.visiting[key] = true
+	return key, nil
+}
+
+// convert convert structs found in v to maps, other values are returned as is
+func (c *mapConverter) convert(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return v.Interface(), nil
+		}
+		if e := v.Elem(); e.Kind() == reflect.Struct && !keepAsIs(e.Type()) {
+			if v.Kind() == reflect.Pointer {
+				key, err := c.enter(v)
+				if err != nil {
+					return nil, err
+				}
+				defer delete(c.visiting, key)
+			}
+			return c.structToMap(e)
+		}
+		if v.Kind() == reflect.Interface {
+			return c.convert(v.Elem())
+		}
+	case reflect.Struct:
+		if !keepAsIs(v.Type()) {
+			return c.structToMap(v)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() || !containsStruct(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			key, err := c.enter(v)
+			if err != nil {
+				return nil, err
+			}
+			defer delete(c.visiting, key)
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			e, err := c.convert(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = e
+		}
+		return out, nil
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String || !containsStruct(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+		key, err := c.enter(v)
+		if err != nil {
+			return nil, err
+		}
+		defer delete(c.visiting, key)
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			e, err := c.convert(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			out[iter.Key().String()] = e
+		}
+		return out, nil
+	}
+	return v.Interface(), nil
+}
+
+// containsStruct report element types that may hold structs to convert
+func containsStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return !keepAsIs(t)
+	case reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isEmptyValue report values omitted by "omitempty", like encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
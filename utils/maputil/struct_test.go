@@ -0,0 +1,170 @@
+package maputil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type structBase struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type structAddress struct {
+	City string `json:"city" mapstructure:"town"`
+}
+
+type structUser struct {
+	structBase
+	Name     string            `json:"name" mapstructure:"user_name"`
+	Email    string            `json:"email,omitempty"`
+	Password string            `json:"-"`
+	Avatar   []byte            `json:"avatar"`
+	Address  structAddress     `json:"address"`
+	Previous *structAddress    `json:"previous,omitempty"`
+	Others   []structAddress   `json:"others"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Extra    structExtra       `json:"extra,inline"`
+	Deleted  time.Time         `json:"deleted,omitzero"`
+	NoTag    bool
+	hidden   string
+}
+
+type structExtra struct {
+	Score float64 `json:"score"`
+	Name  string  `json:"name"`
+}
+
+func TestStructToMapWith(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	u := structUser{
+		structBase: structBase{ID: 7, Created: created},
+		Name:       "alice",
+		Password:   "secret",
+		Avatar:     []byte{1, 2},
+		Address:    structAddress{City: "Paris"},
+		Others:     []structAddress{{City: "Rome"}},
+		Extra:      structExtra{Score: 1.5, Name: "shadowed"},
+		hidden:     "x",
+	}
+	m, err := StructToMapWith(&u, StructMapOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := map[string]interface{}{
+		"id":      7,
+		"created": created,
+		"name":    "alice",
+		"avatar":  []byte{1, 2},
+		"address": structAddress{City: "Paris"},
+		"others":  []structAddress{{City: "Rome"}},
+		"score":   1.5,
+		"NoTag":   false,
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("expected %#v, got %#v", want, m)
+	}
+}
+
+func TestStructToMapWithRecursive(t *testing.T) {
+	u := structUser{
+		Name:     "bob",
+		Email:    "bob@example.com",
+		Previous: &structAddress{City: "Oslo"},
+		Others:   []structAddress{{City: "Rome"}, {City: "Lima"}},
+		Labels:   map[string]string{"team": "infra"},
+		Deleted:  time.Unix(0, 0).UTC(),
+	}
+	m, err := StructToMapWith(u, StructMapOptions{TagName: "mapstructure", Recursive: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if m["user_name"] != "bob" || m["Email"] != "bob@example.com" {
+		t.Fatalf("expected mapstructure names, got %#v", m)
+	}
+	if !reflect.DeepEqual(m["Address"], map[string]interface{}{"town": ""}) {
+		t.Fatalf("expected nested map, got %#v", m["Address"])
+	}
+	if !reflect.DeepEqual(m["Previous"], map[string]interface{}{"town": "Oslo"}) {
+		t.Fatalf("expected pointer converted, got %#v", m["Previous"])
+	}
+	others := []interface{}{map[string]interface{}{"town": "Rome"}, map[string]interface{}{"town": "Lima"}}
+	if !reflect.DeepEqual(m["Others"], others) {
+		t.Fatalf("expected slice converted, got %#v", m["Others"])
+	}
+	if _, ok := m["Deleted"].(time.Time); !ok {
+		t.Fatalf("expected time.Time kept, got %#v", m["Deleted"])
+	}
+	if !reflect.DeepEqual(m["Labels"], map[string]string{"team": "infra"}) {
+		t.Fatalf("expected labels kept, got %#v", m["Labels"])
+	}
+	if _, ok := m["ID"]; !ok {
+		t.Fatalf("expected embedded fields flattened, got %#v", m)
+	}
+}
+
+type structNode struct {
+	Name  string      `json:"name"`
+	Next  *structNode `json:"next,omitempty"`
+	Items []interface{}
+}
+
+type structEmbeddedPtr struct {
+	*structBase
+	Name string `json:"name"`
+}
+
+func TestStructToMapWithEdgeCases(t *testing.T) {
+	m, err := StructToMapWith(structEmbeddedPtr{Name: "x"}, StructMapOptions{})
+	if err != nil || !reflect.DeepEqual(m, map[string]interface{}{"name": "x"}) {
+		t.Fatalf("expected nil embedded pointer skipped, got %#v, %v", m, err)
+	}
+
+	n := structNode{Name: "a", Next: &structNode{Name: "b"}, Items: []interface{}{structAddress{City: "c"}, 1}}
+	m, err = StructToMapWith(n, StructMapOptions{Recursive: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	next, ok := m["next"].(map[string]interface{})
+	if !ok || next["name"] != "b" {
+		t.Fatalf("expected recursive pointer, got %#v", m["next"])
+	}
+	items := m["Items"].([]interface{})
+	if !reflect.DeepEqual(items[0], map[string]interface{}{"city": "c"}) || items[1] != 1 {
+		t.Fatalf("expected interface slice converted, got %#v", items)
+	}
+
+	// a shared pointer is not a cycle
+	shared := &structNode{Name: "s"}
+	m, err = StructToMapWith(structNode{Items: []interface{}{shared, shared}}, StructMapOptions{Recursive: true})
+	if err != nil || len(m["Items"].([]interface{})) != 2 {
+		t.Fatalf("expected shared pointer converted twice, got %#v, %v", m, err)
+	}
+
+	cyclic := &structNode{Name: "loop"}
+	cyclic.Next = cyclic
+	if _, err := StructToMapWith(cyclic, StructMapOptions{Recursive: true}); !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle for pointer cycle, got %v", err)
+	}
+	loop := []interface{}{structAddress{City: "x"}, nil}
+	loop[1] = loop
+	if _, err := StructToMapWith(structNode{Items: loop}, StructMapOptions{Recursive: true}); !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle for slice cycle, got %v", err)
+	}
+	self := map[string]interface{}{}
+	self["self"] = self
+	if _, err := StructToMapWith(structNode{Items: []interface{}{self}}, StructMapOptions{Recursive: true}); !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle for map cycle, got %v", err)
+	}
+	if _, err := StructToMapWith(cyclic, StructMapOptions{}); err != nil {
+		t.Fatalf("expected no error without recursion, got %v", err)
+	}
+
+	for _, in := range []interface{}{nil, 1, (*structUser)(nil), []structUser{}} {
+		if _, err := StructToMapWith(in, StructMapOptions{}); !errors.Is(err, ErrNotStruct) {
+			t.Fatalf("expected ErrNotStruct for %#v, got %v", in, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package stringutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Words split str into words for case conversion: any rune other than a letter or digit separates words,
+// and a new word starts at a lower to upper case change, at the last upper case letter of an acronym
+// followed by a lower case letter, and at an upper case letter following a digit
+// exp: "HTTPServer" -> ["HTTP", "Server"], "userIDs" -> ["user", "IDs"], "api-v2.json_file" -> ["api", "v2", "json", "file"]
+func Words(str string) []string {
+	var words []string
+	runes := []rune(str)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		if wordBoundary(runes, i) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// wordBoundary check if a new word starts at runes[i], runes[i-1] is in the current word
+func wordBoundary(runes []rune, i int) bool {
+	prev, cur := runes[i-1], runes[i]
+	if !unicode.IsUpper(cur) {
+		return false
+	}
+	if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+		return true
+	}
+	// "HTTPServer": the "S" starts a new word as it is followed by a lower case letter,
+	// except for plural acronyms like "IDs"
+	if !unicode.IsUpper(prev) || i+1 >= len(runes) || !unicode.IsLower(runes[i+1]) {
+		return false
+	}
+	return runes[i+1] != 's' || (i+2 < len(runes) && unicode.IsLower(runes[i+2]))
+}
+
+// SnakeCase convert string to snake case, upper case words made of DefaultInitialisms are split
+// exp: "HelloWorld" -> "hello_world", "HTTPServer" -> "http_server", "HTTPURL" -> "http_url"
+func SnakeCase(str string) string {
+	return joinWords(splitWords(str, DefaultInitialisms), "_", strings.ToLower)
+}
+
+// ScreamingSnakeCase convert string to screaming snake case
+// exp: "helloWorld" -> "HELLO_WORLD"
+func ScreamingSnakeCase(str string) string {
+	return joinWords(splitWords(str, DefaultInitialisms), "_", strings.ToUpper)
+}
+
+// KebabCase convert string to kebab case
+// exp: "HelloWorld" -> "hello-world"
+func KebabCase(str string) string {
+	return joinWords(splitWords(str, DefaultInitialisms), "-", strings.ToLower)
+}
+
+// DotCase convert string to dot case
+// exp: "HelloWorld" -> "hello.world"
+func DotCase(str string) string {
+	return joinWords(splitWords(str, DefaultInitialisms), ".", strings.ToLower)
+}
+
+// CamelCase convert string to camel case, DefaultInitialisms are upper cased
+// exp: "hello_world" -> "helloWorld", "Hello World" -> "helloWorld", "user_id" -> "userID"
+func CamelCase(str string) string {
+	return CamelCaseWith(str, DefaultInitialisms)
+}
+
+// PascalCase convert string to pascal case, DefaultInitialisms are upper cased
+// exp: "hello_world" -> "HelloWorld", "http_url" -> "HTTPURL"
+func PascalCase(str string) string {
+	return PascalCaseWith(str, DefaultInitialisms)
+}
+
+// TitleCase convert string to space separated capitalized words, DefaultInitialisms are upper cased
+// exp: "hello_world" -> "Hello World"
+func TitleCase(str string) string {
+	return TitleCaseWith(str, DefaultInitialisms)
+}
+
+// CamelCaseToSpaceSeparated convert camel case string to space separated string
+// exp: "helloWorld" -> "hello World"
+func CamelCaseToSpaceSeparated(str string) string {
+	var b strings.Builder
+	for i, r := range str {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func joinWords(words []string, sep string, convert func(string) string) string {
+	var b strings.Builder
+	for i, w := range words {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(convert(w))
+	}
+	return b.String()
+}
+
+// capitalize upper the first letter and lower the others
+func capitalize(word string) string {
+	return UpperFirst(strings.ToLower(word))
+}
@@ -0,0 +1,112 @@
+package stringutil
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zeroWidthJoiner     = 0x200D
+	variationSelector16 = 0xFE0F
+)
+
+// Graphemes split str into user perceived characters (extended grapheme clusters),
+// combining marks, emoji modifiers, ZWJ sequences and flags are kept together
+// exp: "é👍🏽🇨🇳" -> ["é", "👍🏽", "🇨🇳"]
+func Graphemes(str string) []string {
+	var clusters []string
+	for str != "" {
+		var g string
+		g, str = nextGrapheme(str)
+		clusters = append(clusters, g)
+	}
+	return clusters
+}
+
+// GraphemeCount return the number of user perceived characters in str
+func GraphemeCount(str string) int {
+	n := 0
+	for str != "" {
+		_, str = nextGrapheme(str)
+		n++
+	}
+	return n
+}
+
+// nextGrapheme split the first grapheme cluster from str, it is a simplified version of
+// the Unicode UAX #29 rules covering combining marks, emoji sequences and regional indicators
+func nextGrapheme(str string) (cluster, rest string) {
+	prev, size := utf8.DecodeRuneInString(str)
+	end := size
+	regional := 0
+	if isRegionalIndicator(prev) {
+		regional = 1
+	}
+	for end < len(str) {
+		r, size := utf8.DecodeRuneInString(str[end:])
+		if !continueGrapheme(prev, r, regional) {
+			break
+		}
+		if isRegionalIndicator(r) {
+			regional++
+		}
+		prev = r
+		end += size
+	}
+	return str[:end], str[end:]
+}
+
+// continueGrapheme check if r belongs to the same cluster as prev,
+// regional is the number of regional indicators already in the cluster
+func continueGrapheme(prev, r rune, regional int) bool {
+	switch {
+	case prev == '\r' && r == '\n':
+		return true
+	case isControl(prev) || isControl(r):
+		return false
+	case isGraphemeExtend(r):
+		return true
+	case prev == zeroWidthJoiner:
+		return true
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		return regional%2 == 1
+	}
+	return false
+}
+
+func isControl(r rune) bool {
+	return r == '\r' || r == '\n' || unicode.IsControl(r)
+}
+
+// isGraphemeExtend report runes that never start a cluster
+func isGraphemeExtend(r rune) bool {
+	return r == zeroWidthJoiner ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || (r >= 0xE0100 && r <= 0xE01EF) ||
+		// emoji skin tone modifiers and tag characters of subdivision flags
+		(r >= 0x1F3FB && r <= 0x1F3FF) || (r >= 0xE0020 && r <= 0xE007F) ||
+		// Hangul vowel and trailing consonant jamo
+		(r >= 0x1160 && r <= 0x11FF)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// graphemeWidth return the number of terminal cells used by a grapheme cluster
+func graphemeWidth(cluster string) int {
+	first, size := utf8.DecodeRuneInString(cluster)
+	if isRegionalIndicator(first) {
+		return 2
+	}
+	width := RuneWidth(first)
+	if width == 1 {
+		// VS16 request the emoji presentation, exp: "❤️"
+		for _, r := range cluster[size:] {
+			if r == variationSelector16 {
+				return 2
+			}
+		}
+	}
+	return width
+}
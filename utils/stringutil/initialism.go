@@ -0,0 +1,153 @@
+package stringutil
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DefaultInitialisms is the registry consulted by the case conversion functions, it starts with the golint set
+// exp: DefaultInitialisms.Add("SKU") makes PascalCase("sku_id") return "SKUID"
+var DefaultInitialisms = NewInitialisms(
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP",
+	"JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL",
+	"UDP", "UI", "UID", "UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+)
+
+// Initialisms is a set of initialisms written in upper case by CamelCase, PascalCase and TitleCase,
+// it is safe for concurrent use
+type Initialisms struct {
+	mu     sync.RWMutex
+	set    map[string]struct{}
+	maxLen int
+}
+
+// NewInitialisms create a set of initialisms, words are case insensitive
+func NewInitialisms(words ...string) *Initialisms {
+	i := &Initialisms{set: make(map[string]struct{})}
+	i.Add(words...)
+	return i
+}
+
+// Add add words to the set
+func (i *Initialisms) Add(words ...string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, w := range words {
+		w = strings.ToUpper(w)
+		i.set[w] = struct{}{}
+		i.maxLen = max(i.maxLen, len(w))
+	}
+}
+
+// Remove remove words from the set
+func (i *Initialisms) Remove(words ...string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, w := range words {
+		delete(i.set, strings.ToUpper(w))
+	}
+}
+
+// Has check if word is in the set, case insensitive
+func (i *Initialisms) Has(word string) bool {
+	if i == nil {
+		return false
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	_, ok := i.set[strings.ToUpper(word)]
+	return ok
+}
+
+// With return a copy of the set with words added, for per call overrides
+// exp: PascalCaseWith("sku_id", DefaultInitialisms.With("SKU")) -> "SKUID"
+func (i *Initialisms) With(words ...string) *Initialisms {
+	c := NewInitialisms()
+	if i != nil {
+		i.mu.RLock()
+		for w := range i.set {
+			c.set[w] = struct{}{}
+		}
+		c.maxLen = i.maxLen
+		i.mu.RUnlock()
+	}
+	c.Add(words...)
+	return c
+}
+
+// CamelCaseWith convert string to camel case, words of initialisms are upper cased, nil means none
+// exp: CamelCaseWith("user_id", DefaultInitialisms) -> "userID"
+func CamelCaseWith(str string, initialisms *Initialisms) string {
+	words := splitWords(str, initialisms)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.ToLower(words[0]) + joinWords(words[1:], "", initialisms.capitalize)
+}
+
+// PascalCaseWith convert string to pascal case, words of initialisms are upper cased, nil means none
+// exp: PascalCaseWith("http_url", DefaultInitialisms) -> "HTTPURL"
+func PascalCaseWith(str string, initialisms *Initialisms) string {
+	return joinWords(splitWords(str, initialisms), "", initialisms.capitalize)
+}
+
+// TitleCaseWith convert string to title case, words of initialisms are upper cased, nil means none
+// exp: TitleCaseWith("user_id", DefaultInitialisms) -> "User ID"
+func TitleCaseWith(str string, initialisms *Initialisms) string {
+	return joinWords(splitWords(str, initialisms), " ", initialisms.capitalize)
+}
+
+// capitalize upper case initialisms and their plural form, exp: "ids" -> "IDs", and capitalize other words
+func (i *Initialisms) capitalize(word string) string {
+	if i.Has(word) {
+		return strings.ToUpper(word)
+	}
+	if n := len(word); n > 1 && (word[n-1] == 's' || word[n-1] == 'S') && i.Has(word[:n-1]) {
+		return strings.ToUpper(word[:n-1]) + "s"
+	}
+	return capitalize(word)
+}
+
+// splitWords split str like Words, then split upper case words made of several initialisms,
+// exp: "HTTPURL" -> ["HTTP", "URL"]
+func splitWords(str string, initialisms *Initialisms) []string {
+	words := Words(str)
+	if initialisms == nil {
+		return words
+	}
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		out = append(out, initialisms.split(w)...)
+	}
+	return out
+}
+
+// split split an upper case word into initialisms, the word is kept as is if it can not be fully covered
+func (i *Initialisms) split(word string) []string {
+	if i.Has(word) || strings.IndexFunc(word, unicode.IsLower) >= 0 {
+		return []string{word}
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if parts := i.cover(word); len(parts) > 1 {
+		return parts
+	}
+	return []string{word}
+}
+
+// cover find the longest-first sequence of initialisms covering word, must be called with i.mu held
+func (i *Initialisms) cover(word string) []string {
+	if word == "" {
+		return []string{}
+	}
+	for n := min(i.maxLen, len(word)); n > 0; n-- {
+		if _, ok := i.set[word[:n]]; !ok {
+			continue
+		}
+		if rest := i.cover(word[n:]); rest != nil {
+			return append([]string{word[:n]}, rest...)
+		}
+	}
+	return nil
+}
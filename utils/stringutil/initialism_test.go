@@ -0,0 +1,58 @@
+package stringutil
+
+import "testing"
+
+func TestInitialismCaseConversion(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		camel  string
+		pascal string
+		snake  string
+	}{
+		{name: "suffix", in: "user_id", camel: "userID", pascal: "UserID", snake: "user_id"},
+		{name: "joined", in: "http_url", camel: "httpURL", pascal: "HTTPURL", snake: "http_url"},
+		{name: "plural", in: "user_ids", camel: "userIDs", pascal: "UserIDs", snake: "user_ids"},
+		{name: "fromGo", in: "ServeHTTPURLJSON", camel: "serveHTTPURLJSON", pascal: "ServeHTTPURLJSON", snake: "serve_http_url_json"},
+		{name: "notInitialism", in: "idea_box", camel: "ideaBox", pascal: "IdeaBox", snake: "idea_box"},
+		{name: "uncoveredAcronym", in: "XYZService", camel: "xyzService", pascal: "XyzService", snake: "xyz_service"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CamelCase(tt.in); got != tt.camel {
+				t.Fatalf("CamelCase(%q) = %q, want %q", tt.in, got, tt.camel)
+			}
+			if got := PascalCase(tt.in); got != tt.pascal {
+				t.Fatalf("PascalCase(%q) = %q, want %q", tt.in, got, tt.pascal)
+			}
+			if got := SnakeCase(tt.in); got != tt.snake {
+				t.Fatalf("SnakeCase(%q) = %q, want %q", tt.in, got, tt.snake)
+			}
+			if got := SnakeCase(PascalCase(tt.in)); got != tt.snake {
+				t.Fatalf("SnakeCase(PascalCase(%q)) = %q, want %q", tt.in, got, tt.snake)
+			}
+		})
+	}
+}
+
+func TestInitialismsOverride(t *testing.T) {
+	custom := DefaultInitialisms.With("SKU")
+	if got := PascalCaseWith("sku_id", custom); got != "SKUID" {
+		t.Fatalf("expected per call initialism, got %q", got)
+	}
+	if got := PascalCase("sku_id"); got != "SkuID" {
+		t.Fatalf("expected default registry untouched, got %q", got)
+	}
+	if got := CamelCaseWith("user_id", nil); got != "userId" {
+		t.Fatalf("expected no initialism with nil set, got %q", got)
+	}
+	if got := TitleCaseWith("api_key", NewInitialisms("api")); got != "API Key" {
+		t.Fatalf("expected case insensitive initialism, got %q", got)
+	}
+
+	custom.Remove("ID")
+	if custom.Has("id") || !DefaultInitialisms.Has("id") {
+		t.Fatalf("expected removal to only affect the copy")
+	}
+}
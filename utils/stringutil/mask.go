@@ -0,0 +1,244 @@
+package stringutil
+
+import (
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultMaskRune is the rune replacing hidden characters
+const DefaultMaskRune = '*'
+
+// Mask replace the runes of str with '*' except keepStart leading and keepEnd trailing runes,
+// str is fully masked when it is too short to hide anything
+// exp: Mask("1234567890", 2, 2) -> "12******90"
+func Mask(str string, keepStart, keepEnd int) string {
+	return MaskWith(str, keepStart, keepEnd, DefaultMaskRune)
+}
+
+// MaskWith replace the runes of str with mask like Mask
+// exp: MaskWith("secret", 1, 0, '#') -> "s#####"
+func MaskWith(str string, keepStart, keepEnd int, mask rune) string {
+	runes := []rune(str)
+	keepStart, keepEnd = max(keepStart, 0), max(keepEnd, 0)
+	if keepStart+keepEnd >= len(runes) {
+		keepStart, keepEnd = 0, 0
+	}
+	for i := keepStart; i < len(runes)-keepEnd; i++ {
+		runes[i] = mask
+	}
+	return string(runes)
+}
+
+// MaskEmail mask the local part of an email address except its first rune, the domain is kept,
+// str is fully masked if it is not an email address
+// exp: "john.doe@example.com" -> "j*******@example.com"
+func MaskEmail(str string) string {
+	i := strings.LastIndexByte(str, '@')
+	if i <= 0 || i == len(str)-1 {
+		return Mask(str, 0, 0)
+	}
+	local := str[:i]
+	if len([]rune(local)) == 1 {
+		return "*" + str[i:]
+	}
+	return Mask(local, 1, 0) + str[i:]
+}
+
+// MaskPhone mask the digits of a phone number except the last 4, and the first 3 of long numbers,
+// formatting characters are kept
+// exp: "13812345678" -> "138****5678", "+1 (555) 123-4567" -> "+1 (55*) ***-4567"
+func MaskPhone(str string) string {
+	digits := 0
+	for _, r := range str {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	keepStart, keepEnd := 0, min(4, digits/2)
+	if digits >= 10 {
+		keepStart = 3
+	}
+	return maskDigits(str, keepStart, keepEnd)
+}
+
+// MaskIP mask the host part of an IP address: the last two octets of an IPv4 address and
+// the last five groups of an IPv6 address, str is fully masked if it is not an IP address
+// exp: "192.168.1.23" -> "192.168.*.*", "2001:db8:85a3::8a2e:370:7334" -> "2001:db8:85a3:*:*:*:*:*"
+func MaskIP(str string) string {
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return Mask(str, 0, 0)
+	}
+	if v4 := ip.To4(); v4 != nil && !strings.Contains(str, ":") {
+		parts := strings.Split(v4.String(), ".")
+		return parts[0] + "." + parts[1] + ".*.*"
+	}
+	groups := make([]string, 8)
+	for i := range groups {
+		if i < 3 {
+			groups[i] = strconv.FormatUint(uint64(ip[2*i])<<8|uint64(ip[2*i+1]), 16)
+		} else {
+			groups[i] = "*"
+		}
+	}
+	return strings.Join(groups, ":")
+}
+
+// MaskCreditCard mask all digits of a card number but the last 4, separators are kept,
+// str is returned as is if it is not a Luhn valid number of 12 to 19 digits
+// exp: "4111 1111 1111 1111" -> "**** **** **** 1111"
+func MaskCreditCard(str string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, str)
+	if len(digits) < 12 || len(digits) > 19 || !LuhnValid(digits) {
+		return str
+	}
+	return maskDigits(str, 0, 4)
+}
+
+// LuhnValid check the Luhn checksum of a number made of digits, exp: credit card numbers
+func LuhnValid(number string) bool {
+	if len(number) < 2 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// MaskJWT keep the header of a JSON Web Token and mask its payload and signature,
+// str is fully masked if it is not a JWT
+// exp: "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig" -> "eyJhbGciOiJIUzI1NiJ9.***.***"
+func MaskJWT(str string) string {
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || !isBase64URL(parts[0]+parts[1]+parts[2]) {
+		return Mask(str, 0, 0)
+	}
+	return parts[0] + ".***.***"
+}
+
+var (
+	jwtPattern   = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	ipv6Pattern  = regexp.MustCompile(`(?:[0-9A-Fa-f]{1,4})?(?::[0-9A-Fa-f]{0,4}){2,7}`)
+	ipv4Pattern  = regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`)
+	cardPattern  = regexp.MustCompile(`\b(?:\d[ -]?){11,18}\d\b`)
+	phonePattern = regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{1,4}\)[ .-]?)?\b\d{3,4}[ .-]?\d{3,4}(?:[ .-]?\d{1,4})?\b`)
+)
+
+// sensitiveDetectors are tried in order, earlier detectors win on overlapping matches
+var sensitiveDetectors = []struct {
+	pattern *regexp.Regexp
+	mask    func(string) (string, bool)
+}{
+	{jwtPattern, func(s string) (string, bool) { return MaskJWT(s), true }},
+	{emailPattern, func(s string) (string, bool) { return MaskEmail(s), true }},
+	{ipv6Pattern, func(s string) (string, bool) {
+		ip := net.ParseIP(s)
+		return MaskIP(s), ip != nil && ip.To4() == nil && strings.Count(s, ":") >= 2
+	}},
+	{ipv4Pattern, func(s string) (string, bool) { return MaskIP(s), true }},
+	{cardPattern, func(s string) (string, bool) {
+		masked := MaskCreditCard(s)
+		return masked, masked != s
+	}},
+	{phonePattern, func(s string) (string, bool) { return MaskPhone(s), true }},
+}
+
+// MaskSensitive mask the JWTs, email addresses, IP addresses, credit card numbers and phone numbers
+// found in text, detection is heuristic and meant for logs
+// exp: "user john@example.com from 10.0.3.7" -> "user j***@example.com from 10.0.*.*"
+func MaskSensitive(text string) string {
+	type span struct {
+		start, end int
+		masked     string
+	}
+	var spans []span
+	overlaps := func(start, end int) bool {
+		for _, s := range spans {
+			if start < s.end && s.start < end {
+				return true
+			}
+		}
+		return false
+	}
+	for _, d := range sensitiveDetectors {
+		for _, loc := range d.pattern.FindAllStringIndex(text, -1) {
+			if overlaps(loc[0], loc[1]) {
+				continue
+			}
+			if masked, ok := d.mask(text[loc[0]:loc[1]]); ok {
+				spans = append(spans, span{loc[0], loc[1], masked})
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return text
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	var b strings.Builder
+	last := 0
+	for _, s := range spans {
+		b.WriteString(text[last:s.start])
+		b.WriteString(s.masked)
+		last = s.end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// maskDigits mask the digits of str except keepStart leading and keepEnd trailing digits
+func maskDigits(str string, keepStart, keepEnd int) string {
+	total := 0
+	for _, r := range str {
+		if unicode.IsDigit(r) {
+			total++
+		}
+	}
+	if keepStart+keepEnd >= total {
+		keepStart, keepEnd = 0, 0
+	}
+	n := 0
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsDigit(r) {
+			return r
+		}
+		n++
+		if n <= keepStart || n > total-keepEnd {
+			return r
+		}
+		return DefaultMaskRune
+	}, str)
+}
+
+func isBase64URL(s string) bool {
+	for _, r := range s {
+		if !(r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,293 @@
+package stringutil
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// AmbiguousChars are characters easily confused when read, removed by PasswordPolicy.ExcludeAmbiguous
+	AmbiguousChars = "0Oo1lI|"
+	// DefaultPasswordLength is used when neither Length nor MinLength is set
+	DefaultPasswordLength = 16
+	// maxPasswordAttempts bound the retries done to satisfy MaxRepeat
+	maxPasswordAttempts = 1000
+)
+
+// ErrPasswordPolicy is returned for a policy that can not be satisfied or a password that does not satisfy it
+var ErrPasswordPolicy = errors.New("stringutil: password policy")
+
+// PasswordPolicy describe the passwords to generate or accept, the zero value is 16 characters from all classes
+type PasswordPolicy struct {
+	// Length is the length of generated passwords, MinLength or DefaultPasswordLength if zero
+	Length int
+	// MinLength and MaxLength bound the length, 0 means no bound
+	MinLength int
+	MaxLength int
+	// MinLower, MinUpper, MinDigits and MinSymbols are the minimum number of characters of each class
+	MinLower   int
+	MinUpper   int
+	MinDigits  int
+	MinSymbols int
+	// NoLower, NoUpper, NoDigits and NoSymbols disable a class
+	NoLower   bool
+	NoUpper   bool
+	NoDigits  bool
+	NoSymbols bool
+	// ExcludeAmbiguous remove AmbiguousChars from every class
+	ExcludeAmbiguous bool
+	// Exclude is a set of extra characters never used
+	Exclude string
+	// MaxRepeat is the max length of a run of the same character, 0 means no limit
+	// exp: MaxRepeat 1 rejects "aa"
+	MaxRepeat int
+}
+
+// Secret is a generated password or token with its estimated entropy in bits
+type Secret struct {
+	Value   string
+	Entropy float64
+}
+
+// String return the secret value
+func (s Secret) String() string {
+	return s.Value
+}
+
+type charClass struct {
+	name     string
+	chars    []rune
+	min      int
+	disabled bool
+}
+
+// GeneratePassword generate a password satisfying policy from crypto/rand
+// exp: GeneratePassword(PasswordPolicy{Length: 20, MinDigits: 2, ExcludeAmbiguous: true})
+func GeneratePassword(policy PasswordPolicy) (Secret, error) {
+	return GeneratePasswordFrom(rand.Reader, policy)
+}
+
+// GeneratePasswordFrom generate a password satisfying policy from src
+func GeneratePasswordFrom(src RandomSource, policy PasswordPolicy) (Secret, error) {
+	if err := policy.Validate(); err != nil {
+		return Secret{}, err
+	}
+	length := policy.length()
+	classes := policy.classes()
+	pool := policy.pool()
+	for attempt := 0; attempt < maxPasswordAttempts; attempt++ {
+		out := make([]rune, 0, length)
+		for _, c := range classes {
+			if c.disabled {
+				continue
+			}
+			for i := 0; i < c.min; i++ {
+				r, err := randomRune(src, c.chars)
+				if err != nil {
+					return Secret{}, err
+				}
+				out = append(out, r)
+			}
+		}
+		for len(out) < length {
+			r, err := randomRune(src, pool)
+			if err != nil {
+				return Secret{}, err
+			}
+			out = append(out, r)
+		}
+		if err := shuffleRunes(src, out); err != nil {
+			return Secret{}, err
+		}
+		if policy.MaxRepeat > 0 && longestRun(out) > policy.MaxRepeat {
+			continue
+		}
+		return Secret{Value: string(out), Entropy: entropy(length, len(pool))}, nil
+	}
+	return Secret{}, fmt.Errorf("%w: no password without runs longer than %d found", ErrPasswordPolicy, policy.MaxRepeat)
+}
+
+// GenerateToken generate an alphanumeric token from crypto/rand, for API keys and reset links
+func GenerateToken(length int) (Secret, error) {
+	charset := Lowercase + Uppercase + Digits
+	value, err := SecureRandomStringWithCharset(length, charset)
+	if err != nil {
+		return Secret{}, err
+	}
+	return Secret{Value: value, Entropy: entropy(length, len(charset))}, nil
+}
+
+// Validate check that the policy can be satisfied
+func (p PasswordPolicy) Validate() error {
+	if p.MinLength < 0 || p.MaxLength < 0 || p.Length < 0 || p.MaxRepeat < 0 {
+		return fmt.Errorf("%w: negative length", ErrPasswordPolicy)
+	}
+	if p.MaxLength > 0 && p.MinLength > p.MaxLength {
+		return fmt.Errorf("%w: min length %d greater than max length %d", ErrPasswordPolicy, p.MinLength, p.MaxLength)
+	}
+	length := p.length()
+	if err := p.checkLength(length); err != nil {
+		return err
+	}
+	required := 0
+	for _, c := range p.classes() {
+		if c.min < 0 {
+			return fmt.Errorf("%w: negative minimum of %s", ErrPasswordPolicy, c.name)
+		}
+		if c.min > 0 && (c.disabled || len(c.chars) == 0) {
+			return fmt.Errorf("%w: %s required but not available", ErrPasswordPolicy, c.name)
+		}
+		if !c.disabled {
+			required += c.min
+		}
+	}
+	if required > length {
+		return fmt.Errorf("%w: %d required characters do not fit in length %d", ErrPasswordPolicy, required, length)
+	}
+	pool := p.pool()
+	if len(pool) == 0 {
+		return fmt.Errorf("%w: no character available", ErrPasswordPolicy)
+	}
+	if p.MaxRepeat > 0 && len(pool) == 1 && length > p.MaxRepeat {
+		return fmt.Errorf("%w: a single character can not avoid runs longer than %d", ErrPasswordPolicy, p.MaxRepeat)
+	}
+	return nil
+}
+
+// Check check that password satisfies the policy, the returned error describes the first violation
+func (p PasswordPolicy) Check(password string) error {
+	if err := p.checkLength(utf8.RuneCountInString(password)); err != nil {
+		return err
+	}
+	pool := p.pool()
+	for _, r := range password {
+		if !containsRune(pool, r) {
+			return fmt.Errorf("%w: character %q not allowed", ErrPasswordPolicy, r)
+		}
+	}
+	for _, c := range p.classes() {
+		n := 0
+		for _, r := range password {
+			if containsRune(c.chars, r) {
+				n++
+			}
+		}
+		if n < c.min {
+			return fmt.Errorf("%w: %d %s required, got %d", ErrPasswordPolicy, c.min, c.name, n)
+		}
+	}
+	if p.MaxRepeat > 0 && longestRun([]rune(password)) > p.MaxRepeat {
+		return fmt.Errorf("%w: same character repeated more than %d times", ErrPasswordPolicy, p.MaxRepeat)
+	}
+	return nil
+}
+
+func (p PasswordPolicy) length() int {
+	switch {
+	case p.Length > 0:
+		return p.Length
+	case p.MinLength > 0:
+		return p.MinLength
+	case p.MaxLength > 0 && p.MaxLength < DefaultPasswordLength:
+		return p.MaxLength
+	}
+	return DefaultPasswordLength
+}
+
+func (p PasswordPolicy) checkLength(length int) error {
+	if length < p.MinLength {
+		return fmt.Errorf("%w: length %d shorter than %d", ErrPasswordPolicy, length, p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return fmt.Errorf("%w: length %d longer than %d", ErrPasswordPolicy, length, p.MaxLength)
+	}
+	return nil
+}
+
+// classes return the character classes with excluded characters removed
+func (p PasswordPolicy) classes() []charClass {
+	exclude := p.Exclude
+	if p.ExcludeAmbiguous {
+		exclude += AmbiguousChars
+	}
+	filter := func(chars string) []rune {
+		var out []rune
+		for _, r := range chars {
+			if !strings.ContainsRune(exclude, r) {
+				out = append(out, r)
+			}
+		}
+		return out
+	}
+	return []charClass{
+		{name: "lower case letters", chars: filter(Lowercase), min: p.MinLower, disabled: p.NoLower},
+		{name: "upper case letters", chars: filter(Uppercase), min: p.MinUpper, disabled: p.NoUpper},
+		{name: "digits", chars: filter(Digits), min: p.MinDigits, disabled: p.NoDigits},
+		{name: "symbols", chars: filter(Symbols), min: p.MinSymbols, disabled: p.NoSymbols},
+	}
+}
+
+// pool return the characters of all enabled classes
+func (p PasswordPolicy) pool() []rune {
+	var pool []rune
+	for _, c := range p.classes() {
+		if !c.disabled {
+			pool = append(pool, c.chars...)
+		}
+	}
+	return pool
+}
+
+func randomRune(src RandomSource, chars []rune) (rune, error) {
+	i, err := randomIntn(src, len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i], nil
+}
+
+// shuffleRunes shuffle runes in place with the Fisher-Yates algorithm
+func shuffleRunes(src RandomSource, runes []rune) error {
+	for i := len(runes) - 1; i > 0; i-- {
+		j, err := randomIntn(src, i+1)
+		if err != nil {
+			return err
+		}
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return nil
+}
+
+func longestRun(runes []rune) int {
+	longest, run := 0, 0
+	for i, r := range runes {
+		if i > 0 && r == runes[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+func containsRune(runes []rune, r rune) bool {
+	for _, c := range runes {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// entropy estimate the bits of a string of length characters picked uniformly from size characters
+func entropy(length, size int) float64 {
+	if length <= 0 || size <= 1 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(size))
+}
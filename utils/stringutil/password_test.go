@@ -0,0 +1,127 @@
+package stringutil
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PasswordPolicy
+		length int
+	}{
+		{name: "default", policy: PasswordPolicy{}, length: DefaultPasswordLength},
+		{name: "minCounts", policy: PasswordPolicy{Length: 8, MinLower: 2, MinUpper: 2, MinDigits: 2, MinSymbols: 2}, length: 8},
+		{name: "noAmbiguous", policy: PasswordPolicy{Length: 64, ExcludeAmbiguous: true, Exclude: "abc"}, length: 64},
+		{name: "digitsOnly", policy: PasswordPolicy{Length: 12, NoLower: true, NoUpper: true, NoSymbols: true, MaxRepeat: 1}, length: 12},
+		{name: "minLength", policy: PasswordPolicy{MinLength: 24, MaxLength: 32}, length: 24},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				got, err := GeneratePassword(tt.policy)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if n := utf8.RuneCountInString(got.Value); n != tt.length {
+					t.Fatalf("expected length %d, got %d in %q", tt.length, n, got.Value)
+				}
+				if err := tt.policy.Check(got.Value); err != nil {
+					t.Fatalf("generated %q does not satisfy policy: %v", got.Value, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratePasswordExclude(t *testing.T) {
+	policy := PasswordPolicy{Length: 200, ExcludeAmbiguous: true, Exclude: "xyz"}
+	got, err := GeneratePassword(policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.ContainsAny(got.Value, AmbiguousChars+"xyz") {
+		t.Fatalf("excluded characters found in %q", got.Value)
+	}
+}
+
+func TestGeneratePasswordDeterministic(t *testing.T) {
+	policy := PasswordPolicy{Length: 10, MinDigits: 3}
+	a, err := GeneratePasswordFrom(&countingSource{}, policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := GeneratePasswordFrom(&countingSource{}, policy)
+	if a != b {
+		t.Fatalf("expected same password from same source, got %q and %q", a.Value, b.Value)
+	}
+}
+
+func TestPasswordEntropy(t *testing.T) {
+	got, err := GeneratePassword(PasswordPolicy{Length: 10, NoUpper: true, NoSymbols: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 10 * math.Log2(36); math.Abs(got.Entropy-want) > 1e-9 {
+		t.Fatalf("expected entropy %f, got %f", want, got.Entropy)
+	}
+
+	token, err := GenerateToken(32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(token.Value) != 32 || math.Abs(token.Entropy-32*math.Log2(62)) > 1e-9 {
+		t.Fatalf("unexpected token %q with entropy %f", token.Value, token.Entropy)
+	}
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PasswordPolicy
+	}{
+		{name: "minGreaterThanMax", policy: PasswordPolicy{MinLength: 10, MaxLength: 8}},
+		{name: "lengthOutOfRange", policy: PasswordPolicy{Length: 4, MinLength: 8}},
+		{name: "tooManyRequired", policy: PasswordPolicy{Length: 4, MinDigits: 3, MinSymbols: 2}},
+		{name: "disabledRequired", policy: PasswordPolicy{MinDigits: 1, NoDigits: true}},
+		{name: "noCharacters", policy: PasswordPolicy{NoLower: true, NoUpper: true, NoDigits: true, NoSymbols: true}},
+		{name: "excludedClass", policy: PasswordPolicy{MinDigits: 1, Exclude: Digits}},
+		{name: "singleCharRepeat", policy: PasswordPolicy{Length: 3, NoLower: true, NoUpper: true, NoSymbols: true, Exclude: "123456789", MaxRepeat: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); !errors.Is(err, ErrPasswordPolicy) {
+				t.Fatalf("expected ErrPasswordPolicy, got %v", err)
+			}
+			if _, err := GeneratePassword(tt.policy); !errors.Is(err, ErrPasswordPolicy) {
+				t.Fatalf("expected ErrPasswordPolicy, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8, MaxLength: 12, MinUpper: 1, MinDigits: 1, ExcludeAmbiguous: true, MaxRepeat: 2}
+	tests := []struct {
+		in    string
+		valid bool
+	}{
+		{in: "Abcdef23", valid: true},
+		{in: "Abcde23", valid: false},
+		{in: "Abcdefgh23456", valid: false},
+		{in: "abcdef23", valid: false},
+		{in: "Abcdefgh", valid: false},
+		{in: "Abcdef20", valid: false},
+		{in: "Abcccf23", valid: false},
+		{in: "Abccdf23", valid: true},
+	}
+	for _, tt := range tests {
+		if err := policy.Check(tt.in); (err == nil) != tt.valid {
+			t.Fatalf("Check(%q) = %v, expected valid %v", tt.in, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package stringutil
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrEmptyCharset is returned when a secure generator is given an empty charset
+var ErrEmptyCharset = errors.New("stringutil: empty charset")
+
+// RandomSource is a source of random bytes used by the secure generators,
+// crypto/rand.Reader is used by default, tests can inject a deterministic source
+type RandomSource interface {
+	Read(p []byte) (n int, err error)
+}
+
+// SecureRandomString generate a string with default charset from crypto/rand,
+// suitable for tokens, API keys and password resets
+func SecureRandomString(length int) (string, error) {
+	return SecureRandomStringFrom(rand.Reader, length, DefaultCharset)
+}
+
+// SecureRandomStringWithCharset generate a string with custom charset from crypto/rand,
+// every rune of charset is picked with the same probability
+func SecureRandomStringWithCharset(length int, charset string) (string, error) {
+	return SecureRandomStringFrom(rand.Reader, length, charset)
+}
+
+// SecureRandomStringFrom generate a string with custom charset from src
+func SecureRandomStringFrom(src RandomSource, length int, charset string) (string, error) {
+	if length <= 0 {
+		return "", nil
+	}
+	runes := []rune(charset)
+	if len(runes) == 0 {
+		return "", ErrEmptyCharset
+	}
+	out := make([]rune, length)
+	for i := range out {
+		n, err := randomIntn(src, len(runes))
+		if err != nil {
+			return "", err
+		}
+		out[i] = runes[n]
+	}
+	return string(out), nil
+}
+
+// randomIntn return a uniform random int in [0, n) read from src,
+// values above the largest multiple of n are rejected so the result has no modulo bias
+func randomIntn(src RandomSource, n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("stringutil: invalid random range %d", n)
+	}
+	bound := uint64(n)
+	limit := ^uint64(0) - ^uint64(0)%bound
+	var buf [8]byte
+	for {
+		if _, err := io.ReadFull(src, buf[:]); err != nil {
+			return 0, fmt.Errorf("stringutil: read random source: %w", err)
+		}
+		if v := binary.BigEndian.Uint64(buf[:]); v < limit {
+			return int(v % bound), nil
+		}
+	}
+}
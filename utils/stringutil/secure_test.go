@@ -0,0 +1,115 @@
+package stringutil
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// countingSource return 0, 1, 2, ... as big endian uint64 values
+type countingSource struct {
+	n uint64
+}
+
+func (s *countingSource) Read(p []byte) (int, error) {
+	for i := 0; i+8 <= len(p); i += 8 {
+		for j := 0; j < 8; j++ {
+			p[i+j] = byte(s.n >> (56 - 8*j))
+		}
+		s.n++
+	}
+	return len(p), nil
+}
+
+func TestSecureRandomString(t *testing.T) {
+	got, err := SecureRandomString(32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 32 {
+		t.Fatalf("expected length 32, got %d", len(got))
+	}
+	for i := 0; i < len(got); i++ {
+		if !stringsContainsByte(DefaultCharset, got[i]) {
+			t.Fatalf("unexpected character %q at index %d in %q", got[i], i, got)
+		}
+	}
+
+	other, err := SecureRandomString(32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == other {
+		t.Fatalf("expected different strings, got %q twice", got)
+	}
+}
+
+func TestSecureRandomStringWithCharset(t *testing.T) {
+	t.Run("nonPositiveLength", func(t *testing.T) {
+		if got, err := SecureRandomStringWithCharset(0, "abc"); got != "" || err != nil {
+			t.Fatalf("expected empty string, got %q, %v", got, err)
+		}
+	})
+
+	t.Run("emptyCharset", func(t *testing.T) {
+		if _, err := SecureRandomStringWithCharset(5, ""); !errors.Is(err, ErrEmptyCharset) {
+			t.Fatalf("expected ErrEmptyCharset, got %v", err)
+		}
+	})
+
+	t.Run("unicodeCharset", func(t *testing.T) {
+		got, err := SecureRandomStringWithCharset(16, "你好")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n := len([]rune(got)); n != 16 {
+			t.Fatalf("expected 16 runes, got %d in %q", n, got)
+		}
+		if strings.Trim(got, "你好") != "" {
+			t.Fatalf("unexpected characters in %q", got)
+		}
+	})
+}
+
+func TestSecureRandomStringFrom(t *testing.T) {
+	t.Run("deterministic", func(t *testing.T) {
+		got, err := SecureRandomStringFrom(&countingSource{}, 6, "abc")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "abcabc" {
+			t.Fatalf("expected %q, got %q", "abcabc", got)
+		}
+	})
+
+	t.Run("rejectBiasedValues", func(t *testing.T) {
+		// with 3 runes the top value 2^64-1 is above the largest multiple of 3 and must be skipped
+		src := bytes.NewReader(append(bytes.Repeat([]byte{0xff}, 8), 0, 0, 0, 0, 0, 0, 0, 1))
+		got, err := SecureRandomStringFrom(src, 1, "abc")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "b" {
+			t.Fatalf("expected %q, got %q", "b", got)
+		}
+	})
+
+	t.Run("shortSource", func(t *testing.T) {
+		if _, err := SecureRandomStringFrom(bytes.NewReader([]byte{1, 2}), 4, "abc"); err == nil {
+			t.Fatalf("expected error for exhausted source")
+		}
+	})
+
+	t.Run("uniform", func(t *testing.T) {
+		got, err := SecureRandomStringWithCharset(30000, "abc")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, c := range "abc" {
+			if n := strings.Count(got, string(c)); n < 9000 || n > 11000 {
+				t.Fatalf("expected about 10000 %q, got %d", c, n)
+			}
+		}
+	})
+}
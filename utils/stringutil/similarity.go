@@ -0,0 +1,213 @@
+package stringutil
+
+import (
+	"sort"
+	"strings"
+)
+
+// Levenshtein return the minimum number of rune insertions, deletions and substitutions turning a into b
+// exp: Levenshtein("kitten", "sitting") -> 3
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	// one row of the matrix is enough, indexed by the shorter string
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur := min(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], cur
+		}
+	}
+	return row[len(rb)]
+}
+
+// DamerauLevenshtein return the edit distance of a and b counting the transposition of
+// two runes as one edit, transposed runes may be edited again
+// exp: DamerauLevenshtein("ca", "abc") -> 2, Levenshtein("ca", "abc") -> 3
+func DamerauLevenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n, m := len(ra), len(rb)
+	inf := n + m
+	// d is shifted by one row and column holding inf to simplify the transposition lookup
+	d := make([][]int, n+2)
+	for i := range d {
+		d[i] = make([]int, m+2)
+	}
+	d[0][0] = inf
+	for i := 0; i <= n; i++ {
+		d[i+1][0], d[i+1][1] = inf, i
+	}
+	for j := 0; j <= m; j++ {
+		d[0][j+1], d[1][j+1] = inf, j
+	}
+	// last row where each rune was seen in a
+	last := make(map[rune]int)
+	for i := 1; i <= n; i++ {
+		lastCol := 0
+		for j := 1; j <= m; j++ {
+			i1, j1 := last[rb[j-1]], lastCol
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost, lastCol = 0, j
+			}
+			d[i+1][j+1] = min(
+				d[i][j]+cost,
+				d[i+1][j]+1,
+				d[i][j+1]+1,
+				d[i1][j1]+(i-i1-1)+1+(j-j1-1),
+			)
+		}
+		last[ra[i-1]] = i
+	}
+	return d[n+1][m+1]
+}
+
+// Jaro return the Jaro similarity of a and b, from 0 for no match to 1 for equal strings
+func Jaro(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	window := max(max(len(ra), len(rb))/2-1, 0)
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i, r := range ra {
+		for j := max(0, i-window); j < min(len(rb), i+window+1); j++ {
+			if !matchedB[j] && rb[j] == r {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	transpositions, j := 0, 0
+	for i, r := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if r != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	return (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// JaroWinkler return the Jaro similarity of a and b boosted by their common prefix of up to 4 runes,
+// it favors strings that match from the beginning, from 0 to 1
+// exp: JaroWinkler("MARTHA", "MARHTA") -> 0.961
+func JaroWinkler(a, b string) float64 {
+	sim := Jaro(a, b)
+	ra, rb := []rune(a), []rune(b)
+	prefix := 0
+	for prefix < min(len(ra), len(rb), 4) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return sim + float64(prefix)*0.1*(1-sim)
+}
+
+// LongestCommonSubsequence return the longest sequence of runes found in order, not necessarily adjacent, in a and b
+// exp: LongestCommonSubsequence("ABCBDAB", "BDCABA") -> "BDAB"
+func LongestCommonSubsequence(a, b string) string {
+	ra, rb := []rune(a), []rune(b)
+	n, m := len(ra), len(rb)
+	l := make([][]int, n+1)
+	for i := range l {
+		l[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if ra[i] == rb[j] {
+				l[i][j] = l[i+1][j+1] + 1
+			} else {
+				l[i][j] = max(l[i+1][j], l[i][j+1])
+			}
+		}
+	}
+	out := make([]rune, 0, l[0][0])
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case ra[i] == rb[j]:
+			out = append(out, ra[i])
+			i++
+			j++
+		case l[i+1][j] >= l[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return string(out)
+}
+
+// LCSDistance return the number of rune insertions and deletions turning a into b
+// exp: LCSDistance("kitten", "sitting") -> 5
+func LCSDistance(a, b string) int {
+	lcs := len([]rune(LongestCommonSubsequence(a, b)))
+	return len([]rune(a)) + len([]rune(b)) - 2*lcs
+}
+
+// Similarity return the Levenshtein distance of a and b normalized to a score from 0 to 1
+// exp: Similarity("kitten", "sitting") -> 0.571
+func Similarity(a, b string) float64 {
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(longest)
+}
+
+// FuzzyMatch is a candidate matched by FuzzyFind
+type FuzzyMatch struct {
+	// Value is the candidate
+	Value string
+	// Index is the position of the candidate in the list
+	Index int
+	// Score is the similarity from 0 to 1
+	Score float64
+}
+
+// FuzzyFind rank candidates by case insensitive JaroWinkler similarity to query,
+// candidates scoring below threshold are dropped, the best match come first
+// exp: FuzzyFind("stauts", []string{"status", "start", "stash"}, 0.8)[0].Value -> "status"
+func FuzzyFind(query string, candidates []string, threshold float64) []FuzzyMatch {
+	return FuzzyFindWith(query, candidates, threshold, func(a, b string) float64 {
+		return JaroWinkler(strings.ToLower(a), strings.ToLower(b))
+	})
+}
+
+// FuzzyFindWith rank candidates like FuzzyFind with a custom score function, exp: Similarity
+func FuzzyFindWith(query string, candidates []string, threshold float64, score func(a, b string) float64) []FuzzyMatch {
+	var matches []FuzzyMatch
+	for i, c := range candidates {
+		if s := score(query, c); s >= threshold {
+			matches = append(matches, FuzzyMatch{Value: c, Index: i, Score: s})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
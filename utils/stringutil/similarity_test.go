@@ -0,0 +1,98 @@
+package stringutil
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEditDistances(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		lev     int
+		damerau int
+		lcs     string
+		lcsDist int
+	}{
+		{a: "", b: "", lev: 0, damerau: 0, lcs: "", lcsDist: 0},
+		{a: "abc", b: "", lev: 3, damerau: 3, lcs: "", lcsDist: 3},
+		{a: "kitten", b: "sitting", lev: 3, damerau: 3, lcs: "ittn", lcsDist: 5},
+		{a: "ca", b: "abc", lev: 3, damerau: 2, lcs: "a", lcsDist: 3},
+		{a: "abcdef", b: "abdcef", lev: 2, damerau: 1, lcs: "abdef", lcsDist: 2},
+		{a: "ABCBDAB", b: "BDCABA", lev: 5, damerau: 4, lcs: "BDAB", lcsDist: 5},
+		{a: "你好世界", b: "你好界世", lev: 2, damerau: 1, lcs: "你好界", lcsDist: 2},
+	}
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.lev {
+			t.Fatalf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.lev)
+		}
+		if got := Levenshtein(tt.b, tt.a); got != tt.lev {
+			t.Fatalf("Levenshtein(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.lev)
+		}
+		if got := DamerauLevenshtein(tt.a, tt.b); got != tt.damerau {
+			t.Fatalf("DamerauLevenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.damerau)
+		}
+		if got := LongestCommonSubsequence(tt.a, tt.b); got != tt.lcs {
+			t.Fatalf("LongestCommonSubsequence(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.lcs)
+		}
+		if got := LCSDistance(tt.a, tt.b); got != tt.lcsDist {
+			t.Fatalf("LCSDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.lcsDist)
+		}
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		jaro    float64
+		winkler float64
+	}{
+		{a: "MARTHA", b: "MARHTA", jaro: 0.944, winkler: 0.961},
+		{a: "DWAYNE", b: "DUANE", jaro: 0.822, winkler: 0.840},
+		{a: "DIXON", b: "DICKSONX", jaro: 0.767, winkler: 0.813},
+		{a: "abc", b: "abc", jaro: 1, winkler: 1},
+		{a: "abc", b: "xyz", jaro: 0, winkler: 0},
+		{a: "", b: "", jaro: 1, winkler: 1},
+		{a: "a", b: "", jaro: 0, winkler: 0},
+	}
+	for _, tt := range tests {
+		if got := Jaro(tt.a, tt.b); math.Abs(got-tt.jaro) > 0.001 {
+			t.Fatalf("Jaro(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.jaro)
+		}
+		if got := JaroWinkler(tt.a, tt.b); math.Abs(got-tt.winkler) > 0.001 {
+			t.Fatalf("JaroWinkler(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.winkler)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := Similarity("kitten", "sitting"); math.Abs(got-4.0/7) > 1e-9 {
+		t.Fatalf("Similarity = %f", got)
+	}
+	if got := Similarity("", ""); got != 1 {
+		t.Fatalf("Similarity of empty strings = %f", got)
+	}
+}
+
+func TestFuzzyFind(t *testing.T) {
+	candidates := []string{"start", "status", "stash", "commit", "Stats"}
+	got := FuzzyFind("stauts", candidates, 0.85)
+	if len(got) == 0 || got[0].Value != "status" || got[0].Index != 1 {
+		t.Fatalf("expected status first, got %+v", got)
+	}
+	for i, m := range got {
+		if m.Score < 0.85 || m.Value == "commit" {
+			t.Fatalf("unexpected match %+v", m)
+		}
+		if i > 0 && m.Score > got[i-1].Score {
+			t.Fatalf("matches not sorted: %+v", got)
+		}
+	}
+	if got := FuzzyFind("xyz", candidates, 0.5); len(got) != 0 {
+		t.Fatalf("expected no match, got %+v", got)
+	}
+
+	got = FuzzyFindWith("stats", candidates, 0.7, Similarity)
+	if len(got) != 2 || got[0].Value != "status" || got[1].Value != "Stats" {
+		t.Fatalf("unexpected matches %+v", got)
+	}
+}
@@ -0,0 +1,230 @@
+package stringutil
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// latinGroups map accented latin letters to ASCII
+var latinGroups = []struct{ from, to string }{
+	{"ÀÁÂÃÄÅĀĂĄǍ", "A"}, {"àáâãäåāăąǎ", "a"}, {"ÇĆĈĊČ", "C"}, {"çćĉċč", "c"},
+	{"ĎĐÐ", "D"}, {"ďđð", "d"}, {"ÈÉÊËĒĔĖĘĚ", "E"}, {"èéêëēĕėęě", "e"},
+	{"ĜĞĠĢ", "G"}, {"ĝğġģ", "g"}, {"ĤĦ", "H"}, {"ĥħ", "h"},
+	{"ÌÍÎÏĨĪĬĮİǏ", "I"}, {"ìíîïĩīĭįıǐ", "i"}, {"Ĵ", "J"}, {"ĵ", "j"}, {"Ķ", "K"}, {"ķ", "k"},
+	{"ĹĻĽĿŁ", "L"}, {"ĺļľŀł", "l"}, {"ÑŃŅŇ", "N"}, {"ñńņňŉ", "n"},
+	{"ÒÓÔÕÖØŌŎŐǑ", "O"}, {"òóôõöøōŏőǒ", "o"}, {"ŔŖŘ", "R"}, {"ŕŗř", "r"},
+	{"ŚŜŞŠȘ", "S"}, {"śŝşšș", "s"}, {"ŢŤŦȚ", "T"}, {"ţťŧț", "t"},
+	{"ÙÚÛÜŨŪŬŮŰŲǓ", "U"}, {"ùúûüũūŭůűųǔ", "u"}, {"Ŵ", "W"}, {"ŵ", "w"},
+	{"ÝŸŶ", "Y"}, {"ýÿŷ", "y"}, {"ŹŻŽ", "Z"}, {"źżž", "z"},
+	{"Æ", "AE"}, {"æ", "ae"}, {"Œ", "OE"}, {"œ", "oe"}, {"ß", "ss"}, {"Þ", "TH"}, {"þ", "th"},
+}
+
+// romanTable romanize lower case Cyrillic and Greek letters, upper case letters are looked up lower cased
+var romanTable = map[rune]string{
+	// Cyrillic, Russian and Ukrainian
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+	'ά': "a", 'έ': "e", 'ή': "i", 'ί': "i", 'ό': "o", 'ύ': "y", 'ώ': "o", 'ϊ': "i", 'ϋ': "y", 'ΐ': "i", 'ΰ': "y",
+}
+
+var latinTable = func() map[rune]string {
+	t := make(map[rune]string)
+	for _, g := range latinGroups {
+		for _, r := range g.from {
+			t[r] = g.to
+		}
+	}
+	return t
+}()
+
+// Transliterate replace accented latin letters with ASCII and drop combining marks, other runes are kept
+// exp: "Crème Brûlée" -> "Creme Brulee", "Straße" -> "Strasse"
+func Transliterate(str string) string {
+	return transliterate(str, false)
+}
+
+// Romanize transliterate str like Transliterate and romanize Cyrillic and Greek letters
+// exp: "Привет мир" -> "Privet mir", "Αθήνα" -> "Athina"
+func Romanize(str string) string {
+	return transliterate(str, true)
+}
+
+func transliterate(str string, romanize bool) string {
+	var b strings.Builder
+	b.Grow(len(str))
+	for _, r := range str {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+			continue
+		}
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if s, ok := latinTable[r]; ok {
+			b.WriteString(s)
+			continue
+		}
+		if romanize {
+			if s, ok := romanTable[unicode.ToLower(r)]; ok {
+				if unicode.IsUpper(r) {
+					s = UpperFirst(s)
+				}
+				b.WriteString(s)
+				continue
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SlugOptions control how SlugifyWith build a slug
+type SlugOptions struct {
+	// Separator join the words, "-" if empty
+	Separator string
+	// MaxLength is the max number of runes, the slug is cut at a word boundary, 0 means no limit
+	MaxLength int
+	// KeepCase keep the letter case instead of lower casing
+	KeepCase bool
+	// Romanize romanize Cyrillic and Greek letters, other scripts are kept as is
+	Romanize bool
+}
+
+// Slugify build a lower case URL slug from str, accents are transliterated and
+// any run of other characters than letters and digits become a single "-"
+// exp: "Hello, Wörld! 2024" -> "hello-world-2024", "Don't panic" -> "dont-panic"
+func Slugify(str string) string {
+	return SlugifyWith(str, SlugOptions{})
+}
+
+// SlugifyWith build a URL slug from str according to opts
+// exp: SlugifyWith("Привет, мир", SlugOptions{Romanize: true, Separator: "_"}) -> "privet_mir"
+func SlugifyWith(str string, opts SlugOptions) string {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "-"
+	}
+	str = transliterate(str, opts.Romanize)
+	if !opts.KeepCase {
+		str = strings.ToLower(str)
+	}
+	var words []string
+	var word strings.Builder
+	for _, r := range str {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word.WriteRune(r)
+		case r == '\'' || r == '’':
+			// apostrophes do not split words
+		default:
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		}
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+	return joinLimited(words, sep, opts.MaxLength)
+}
+
+// joinLimited join words with sep into at most limit runes, dropping the last words,
+// the first word is cut if it is longer than limit
+func joinLimited(words []string, sep string, limit int) string {
+	slug := strings.Join(words, sep)
+	if limit <= 0 || utf8.RuneCountInString(slug) <= limit {
+		return slug
+	}
+	var b strings.Builder
+	n := 0
+	for i, w := range words {
+		add := utf8.RuneCountInString(w)
+		if i > 0 {
+			add += utf8.RuneCountInString(sep)
+		}
+		if n+add > limit {
+			break
+		}
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(w)
+		n += add
+	}
+	if b.Len() == 0 {
+		return string([]rune(words[0])[:limit])
+	}
+	return b.String()
+}
+
+// maxFilenameBytes is the common file name limit of file systems
+const maxFilenameBytes = 255
+
+// windowsReserved are device names Windows refuses as file names, with or without extension
+var windowsReserved = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// SafeFilename make name safe to use as a file name on common systems: path separators, control and
+// reserved characters are replaced with "_", trailing dots and spaces are trimmed, reserved device names
+// are prefixed with "_" and the name is cut to 255 bytes keeping the extension
+// exp: "../etc/passwd" -> "_etc_passwd", "report: Q1?.pdf" -> "report_ Q1_.pdf", "con.txt" -> "_con.txt"
+func SafeFilename(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range name {
+		if r == utf8.RuneError || unicode.IsControl(r) || strings.ContainsRune(`/\<>:"|?*`, r) {
+			// collapse runs of replaced characters
+			if !underscore {
+				b.WriteByte('_')
+			}
+			underscore = true
+			continue
+		}
+		b.WriteRune(r)
+		underscore = false
+	}
+	safe := strings.TrimRight(b.String(), ". ")
+	safe = strings.TrimLeft(safe, " ")
+	// hidden or relative names like "..": drop leading dots before a separator
+	for strings.HasPrefix(safe, "._") || strings.HasPrefix(safe, ".._") {
+		safe = strings.TrimLeft(safe, ".")
+	}
+	if safe == "" || safe == "_" {
+		return "_"
+	}
+	base := safe
+	if i := strings.IndexByte(base, '.'); i > 0 {
+		base = base[:i]
+	}
+	if windowsReserved[strings.ToUpper(strings.TrimRight(base, " "))] {
+		safe = "_" + safe
+	}
+	return truncateFilename(safe, maxFilenameBytes)
+}
+
+// truncateFilename cut name to limit bytes at a rune boundary, keeping a short extension
+func truncateFilename(name string, limit int) string {
+	if len(name) <= limit {
+		return name
+	}
+	ext := ""
+	if i := strings.LastIndexByte(name, '.'); i > 0 && len(name)-i <= 16 {
+		name, ext = name[:i], name[i:]
+	}
+	limit -= len(ext)
+	for limit > 0 && !utf8.RuneStart(name[limit]) {
+		limit--
+	}
+	return name[:limit] + ext
+}
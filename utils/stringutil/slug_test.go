@@ -0,0 +1,102 @@
+package stringutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Crème Brûlée", want: "Creme Brulee"},
+		{in: "Straße Ærø Œuvre", want: "Strasse AEro OEuvre"},
+		{in: "Łódź Ñandú", want: "Lodz Nandu"},
+		{in: "e\u0301", want: "e"},
+		{in: "Привет 你好", want: "Привет 你好"},
+	}
+	for _, tt := range tests {
+		if got := Transliterate(tt.in); got != tt.want {
+			t.Fatalf("Transliterate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := Romanize("Привет, Щука! Αθήνα Ёж"); got != "Privet, Shchuka! Athina Yozh" {
+		t.Fatalf("Romanize = %q", got)
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "Hello, Wörld! 2024", want: "hello-world-2024"},
+		{in: "  --Multiple   separators__here--  ", want: "multiple-separators-here"},
+		{in: "Don't panic", want: "dont-panic"},
+		{in: "你好 世界", want: "你好-世界"},
+		{in: "!!!", want: ""},
+	}
+	for _, tt := range tests {
+		if got := Slugify(tt.in); got != tt.want {
+			t.Fatalf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSlugifyWith(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		opts SlugOptions
+		want string
+	}{
+		{name: "romanize", in: "Привет, мир", opts: SlugOptions{Romanize: true, Separator: "_"}, want: "privet_mir"},
+		{name: "keepCase", in: "Hello World", opts: SlugOptions{KeepCase: true}, want: "Hello-World"},
+		{name: "wordBoundary", in: "the quick brown fox", opts: SlugOptions{MaxLength: 12}, want: "the-quick"},
+		{name: "exactLength", in: "the quick brown fox", opts: SlugOptions{MaxLength: 15}, want: "the-quick-brown"},
+		{name: "longFirstWord", in: "supercalifragilistic word", opts: SlugOptions{MaxLength: 5}, want: "super"},
+		{name: "noRomanize", in: "Αθήνα", opts: SlugOptions{}, want: "αθήνα"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlugifyWith(tt.in, tt.opts); got != tt.want {
+				t.Fatalf("SlugifyWith(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeFilename(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "report.pdf", want: "report.pdf"},
+		{in: "../etc/passwd", want: "_etc_passwd"},
+		{in: `C:\Users\me`, want: "C_Users_me"},
+		{in: "report: Q1?.pdf", want: "report_ Q1_.pdf"},
+		{in: "con.txt", want: "_con.txt"},
+		{in: "LPT1", want: "_LPT1"},
+		{in: "console.txt", want: "console.txt"},
+		{in: "name. . ", want: "name"},
+		{in: "..", want: "_"},
+		{in: "", want: "_"},
+		{in: ".bashrc", want: ".bashrc"},
+		{in: "tab\there", want: "tab_here"},
+		{in: "文件名.txt", want: "文件名.txt"},
+	}
+	for _, tt := range tests {
+		if got := SafeFilename(tt.in); got != tt.want {
+			t.Fatalf("SafeFilename(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	long := SafeFilename(strings.Repeat("文", 100) + ".txt")
+	if len(long) > 255 || !strings.HasSuffix(long, ".txt") || !strings.HasPrefix(long, "文") {
+		t.Fatalf("unexpected long file name %q (%d bytes)", long, len(long))
+	}
+	if got := truncateFilename(long, 10); got != "文文.txt" {
+		t.Fatalf("truncateFilename = %q", got)
+	}
+}
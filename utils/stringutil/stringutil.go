@@ -2,7 +2,6 @@ package stringutil
 
 import (
 	"math/rand"
-	"strings"
 	"unicode"
 )
 
@@ -14,53 +13,12 @@ const (
 	Symbols        = "!@#$%^&*()-_=+[]{}|;:,.<>?"
 )
 
-// SnakeCase convert string to snake case
-// exp: "HelloWorld" -> "hello_world"
-func SnakeCase(str string) string {
-	var snakeCase strings.Builder
-	for i, r := range str {
-		if unicode.IsUpper(r) && i > 0 {
-			snakeCase.WriteRune('_')
-		}
-		snakeCase.WriteRune(unicode.ToLower(r))
-	}
-	return snakeCase.String()
-}
-
-// CamelCase convert string to camel case
-// exp: "hello_world" -> "helloWorld"
-func CamelCase(str string) string {
-	ws := strings.Split(str, "_")
-	var b strings.Builder
-	wroteFirst := false
-	for _, w := range ws {
-		if w == "" {
-			continue
-		}
-
-		if !wroteFirst {
-			b.WriteString(strings.ToLower(w))
-			wroteFirst = true
-			continue
-		}
-
-		lowered := strings.ToLower(w)
-		runes := []rune(lowered)
-		if len(runes) == 0 {
-			continue
-		}
-		runes[0] = unicode.ToUpper(runes[0])
-		b.WriteString(string(runes))
-	}
-	return b.String()
-}
-
-// RandomString generator with default charset
+// RandomString generator with default charset, it uses math/rand, use SecureRandomString for secrets
 func RandomString(length int) string {
 	return RandomStringWithCharset(length, DefaultCharset)
 }
 
-// RandomStringWithCharset generator with custom charset
+// RandomStringWithCharset generator with custom charset, use SecureRandomStringWithCharset for secrets
 func RandomStringWithCharset(length int, charset string) string {
 	if length <= 0 {
 		return ""
@@ -75,19 +33,6 @@ func RandomStringWithCharset(length int, charset string) string {
 	return string(b)
 }
 
-// CamelCaseToSpaceSeparated convert camel case string to space separated string
-// exp: "helloWorld" -> "hello World"
-func CamelCaseToSpaceSeparated(str string) string {
-	var b strings.Builder
-	for i, r := range str {
-		if i > 0 && unicode.IsUpper(r) {
-			b.WriteRune(' ')
-		}
-		b.WriteRune(r)
-	}
-	return b.String()
-}
-
 // UpperFirst upper first letter of string
 func UpperFirst(input string) string {
 	if input == "" {
@@ -108,17 +53,16 @@ func LowerFirst(input string) string {
 	return string(runes)
 }
 
-// FormatString format string to fixed length
+// FormatString format string to fixed display width, align is "left", "center" or right by default,
+// longer text is truncated without splitting a character
+// exp: FormatString("你好", 6, "left") -> "你好  "
 func FormatString(text string, length int, align string) string {
-	if len(text) >= length {
-		return text
-	}
-	if len(text) >= length {
-		return text[:length]
-	}
-
-	if align == "left" {
-		return text + strings.Repeat(" ", length-len(text))
+	opts := FormatOptions{Align: AlignRight, Truncate: true}
+	switch align {
+	case "left":
+		opts.Align = AlignLeft
+	case "center":
+		opts.Align = AlignCenter
 	}
-	return strings.Repeat(" ", length-len(text)) + text
+	return FormatWidth(text, length, opts)
 }
@@ -37,7 +37,7 @@ func TestCamelCase(t *testing.T) {
 		{name: "single", in: "hello", want: "hello"},
 		{name: "snake", in: "hello_world", want: "helloWorld"},
 		{name: "multiple", in: "hello_world_again", want: "helloWorldAgain"},
-		{name: "alreadyCamelNoUnderscore", in: "helloWorld", want: "helloworld"},
+		{name: "alreadyCamel", in: "helloWorld", want: "helloWorld"},
 		{name: "leadingTrailingUnderscore", in: "_hello_world_", want: "helloWorld"},
 		{name: "doubleUnderscore", in: "hello__world", want: "helloWorld"},
 	}
@@ -104,3 +104,81 @@ func stringsContainsByte(s string, b byte) bool {
 	}
 	return false
 }
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "empty", in: "", want: nil},
+		{name: "acronymPrefix", in: "HTTPServer", want: []string{"HTTP", "Server"}},
+		{name: "acronymSuffix", in: "userID", want: []string{"user", "ID"}},
+		{name: "pluralAcronym", in: "userIDs", want: []string{"user", "IDs"}},
+		{name: "pluralAcronymPrefix", in: "IDsList", want: []string{"IDs", "List"}},
+		{name: "notPlural", in: "HTTPStatus", want: []string{"HTTP", "Status"}},
+		{name: "separators", in: "api-v2.json_file name", want: []string{"api", "v2", "json", "file", "name"}},
+		{name: "digits", in: "version2Beta10x", want: []string{"version2", "Beta10x"}},
+		{name: "screaming", in: "MAX_RETRY_COUNT", want: []string{"MAX", "RETRY", "COUNT"}},
+		{name: "unicode", in: "ÜberCafé", want: []string{"Über", "Café"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Words(tt.in)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Words(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Words(%q) = %q, want %q", tt.in, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCaseConversions(t *testing.T) {
+	tests := []struct {
+		in        string
+		snake     string
+		screaming string
+		kebab     string
+		dot       string
+		camel     string
+		pascal    string
+		title     string
+	}{
+		{in: "HTTPServer", snake: "http_server", screaming: "HTTP_SERVER", kebab: "http-server", dot: "http.server", camel: "httpServer", pascal: "HTTPServer", title: "HTTP Server"},
+		{in: "user_name", snake: "user_name", screaming: "USER_NAME", kebab: "user-name", dot: "user.name", camel: "userName", pascal: "UserName", title: "User Name"},
+		{in: "Hello World", snake: "hello_world", screaming: "HELLO_WORLD", kebab: "hello-world", dot: "hello.world", camel: "helloWorld", pascal: "HelloWorld", title: "Hello World"},
+		{in: "get2FACode", snake: "get2_fa_code", screaming: "GET2_FA_CODE", kebab: "get2-fa-code", dot: "get2.fa.code", camel: "get2FaCode", pascal: "Get2FaCode", title: "Get2 Fa Code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			for _, c := range []struct {
+				fn   func(string) string
+				name string
+				want string
+			}{
+				{SnakeCase, "SnakeCase", tt.snake},
+				{ScreamingSnakeCase, "ScreamingSnakeCase", tt.screaming},
+				{KebabCase, "KebabCase", tt.kebab},
+				{DotCase, "DotCase", tt.dot},
+				{CamelCase, "CamelCase", tt.camel},
+				{PascalCase, "PascalCase", tt.pascal},
+				{TitleCase, "TitleCase", tt.title},
+			} {
+				got := c.fn(tt.in)
+				if got != c.want {
+					t.Fatalf("%s(%q) = %q, want %q", c.name, tt.in, got, c.want)
+				}
+				// every style converts back to the same words
+				if back := SnakeCase(got); back != tt.snake {
+					t.Fatalf("SnakeCase(%s(%q)) = %q, want %q", c.name, tt.in, back, tt.snake)
+				}
+			}
+		})
+	}
+}
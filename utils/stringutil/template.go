@@ -0,0 +1,166 @@
+package stringutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingKey is returned by InterpolateStrict when placeholders have no value nor default
+var ErrMissingKey = errors.New("stringutil: missing template key")
+
+// Interpolate replace "${name}" and "{name}" placeholders in tpl with values from data,
+// names may be dot separated paths into nested maps and slices, exp: "user.name" or "items.0",
+// "${name:-default}" use default when the value is missing or empty,
+// a backslash escape a placeholder, exp: "\${name}" -> "${name}",
+// placeholders without value are kept as is
+// exp: Interpolate("${host}:${port:-8080}", map[string]interface{}{"host": "localhost"}) -> "localhost:8080"
+func Interpolate(tpl string, data map[string]interface{}) string {
+	out, _ := interpolate(tpl, data)
+	return out
+}
+
+// InterpolateStrict replace placeholders like Interpolate, it return an error wrapping ErrMissingKey
+// listing all placeholders without value nor default
+func InterpolateStrict(tpl string, data map[string]interface{}) (string, error) {
+	out, missing := interpolate(tpl, data)
+	if len(missing) > 0 {
+		return out, fmt.Errorf("%w: %s", ErrMissingKey, strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+func interpolate(tpl string, data map[string]interface{}) (string, []string) {
+	var b strings.Builder
+	var missing []string
+	for i := 0; i < len(tpl); {
+		c := tpl[i]
+		if c == '\\' && i+1 < len(tpl) {
+			switch {
+			case strings.HasPrefix(tpl[i+1:], "${"):
+				b.WriteString("${")
+				i += 3
+				continue
+			case tpl[i+1] == '{' || tpl[i+1] == '\\':
+				b.WriteByte(tpl[i+1])
+				i += 2
+				continue
+			}
+		}
+		start := -1
+		if c == '$' && i+1 < len(tpl) && tpl[i+1] == '{' {
+			start = i + 2
+		} else if c == '{' {
+			start = i + 1
+		}
+		end := -1
+		if start >= 0 {
+			end = strings.IndexByte(tpl[start:], '}')
+		}
+		if end < 0 {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		body := tpl[start : start+end]
+		name, def, hasDef := strings.Cut(body, ":-")
+		if !isPlaceholderName(name) {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		placeholder := tpl[i : start+end+1]
+		i = start + end + 1
+		value, ok := lookupPath(data, name)
+		switch {
+		case ok && (value != "" || !hasDef):
+			b.WriteString(value)
+		case hasDef:
+			b.WriteString(def)
+		default:
+			b.WriteString(placeholder)
+			if !containsString(missing, name) {
+				missing = append(missing, name)
+			}
+		}
+	}
+	return b.String(), missing
+}
+
+// isPlaceholderName accept letters, digits, "_", "-" and "." so "{ }" in JSON stays as is
+func isPlaceholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r == '-' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupPath return the value at path in data formatted with fmt, a key containing dots is
+// matched before it is split, nil values are empty
+func lookupPath(data map[string]interface{}, path string) (string, bool) {
+	if v, ok := data[path]; ok {
+		return formatValue(v), true
+	}
+	var cur interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		next, ok := lookupKey(cur, key)
+		if !ok {
+			return "", false
+		}
+		cur = next
+	}
+	return formatValue(cur), true
+}
+
+func lookupKey(v interface{}, key string) (interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		next, ok := m[key]
+		return next, ok
+	case map[string]string:
+		next, ok := m[key]
+		return next, ok
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		next := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+		if !next.IsValid() {
+			return nil, false
+		}
+		return next.Interface(), true
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= rv.Len() {
+			return nil, false
+		}
+		return rv.Index(i).Interface(), true
+	}
+	return nil, false
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
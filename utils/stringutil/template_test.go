@@ -0,0 +1,73 @@
+package stringutil
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	data := map[string]interface{}{
+		"host":  "localhost",
+		"port":  8080,
+		"empty": "",
+		"nil":   nil,
+		"user": map[string]interface{}{
+			"name": "alice",
+			"tags": []interface{}{"admin", "dev"},
+			"meta": map[string]string{"team": "infra"},
+		},
+		"ids":      []int{7, 8},
+		"a.b":      "flat",
+		"settings": map[string]int{"retries": 3},
+	}
+	tests := []struct {
+		name string
+		tpl  string
+		want string
+	}{
+		{name: "dollar", tpl: "${host}:${port}", want: "localhost:8080"},
+		{name: "brace", tpl: "{host}:{port}", want: "localhost:8080"},
+		{name: "nested", tpl: "${user.name} in {user.meta.team}", want: "alice in infra"},
+		{name: "slice", tpl: "${user.tags.1} ${ids.0}", want: "dev 7"},
+		{name: "reflectMap", tpl: "${settings.retries}", want: "3"},
+		{name: "flatKey", tpl: "${a.b}", want: "flat"},
+		{name: "default", tpl: "${timeout:-30s}", want: "30s"},
+		{name: "defaultUnused", tpl: "${port:-9090}", want: "8080"},
+		{name: "defaultEmpty", tpl: "${empty:-none} ${nil:-none}", want: "none none"},
+		{name: "emptyValue", tpl: "[${empty}]", want: "[]"},
+		{name: "defaultWithColon", tpl: "{url:-http://localhost:80}", want: "http://localhost:80"},
+		{name: "missingKept", tpl: "${missing} {user.age} ${ids.5}", want: "${missing} {user.age} ${ids.5}"},
+		{name: "escape", tpl: `\${host} \{host} \\${host}`, want: `${host} {host} \localhost`},
+		{name: "json", tpl: `{"host": "${host}"}`, want: `{"host": "localhost"}`},
+		{name: "unterminated", tpl: "${host", want: "${host"},
+		{name: "lonelyDollar", tpl: "$5 for {host}", want: "$5 for localhost"},
+		{name: "unicode", tpl: "你好 ${user.name}👋", want: "你好 alice👋"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Interpolate(tt.tpl, data); got != tt.want {
+				t.Fatalf("Interpolate(%q) = %q, want %q", tt.tpl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateStrict(t *testing.T) {
+	data := map[string]interface{}{"name": "bob"}
+	got, err := InterpolateStrict("hi ${name}", data)
+	if err != nil || got != "hi bob" {
+		t.Fatalf("unexpected result %q, %v", got, err)
+	}
+
+	got, err = InterpolateStrict("${a} ${name} {b} ${a} ${c:-ok}", data)
+	if !errors.Is(err, ErrMissingKey) {
+		t.Fatalf("expected ErrMissingKey, got %v", err)
+	}
+	if !strings.HasSuffix(err.Error(), ": a, b") {
+		t.Fatalf("expected missing keys a and b, got %v", err)
+	}
+	if got != "${a} bob {b} ${a} ok" {
+		t.Fatalf("unexpected partial result %q", got)
+	}
+}
@@ -0,0 +1,220 @@
+package stringutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Reverse reverse str by grapheme cluster, so combining marks and emoji stay intact
+// exp: "héllo👍🏽" -> "👍🏽olléh"
+func Reverse(str string) string {
+	clusters := Graphemes(str)
+	var b strings.Builder
+	b.Grow(len(str))
+	for i := len(clusters) - 1; i >= 0; i-- {
+		b.WriteString(clusters[i])
+	}
+	return b.String()
+}
+
+// Substr return length grapheme clusters of str starting at start, a negative start count from the end,
+// the range is clamped to str and a negative length mean up to the end
+// exp: Substr("你好世界", 1, 2) -> "好世", Substr("hello", -3, -1) -> "llo"
+func Substr(str string, start, length int) string {
+	clusters := Graphemes(str)
+	n := len(clusters)
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if start >= n || length == 0 {
+		return ""
+	}
+	end := n
+	if length > 0 && start+length < n {
+		end = start + length
+	}
+	return strings.Join(clusters[start:end], "")
+}
+
+// Insert insert sub into str before the grapheme cluster at index, index is clamped to str
+// exp: Insert("你世界", 1, "好") -> "你好世界"
+func Insert(str string, index int, sub string) string {
+	offset := 0
+	for rest := str; rest != "" && index > 0; index-- {
+		var g string
+		g, rest = nextGrapheme(rest)
+		offset += len(g)
+	}
+	return str[:offset] + sub + str[offset:]
+}
+
+// Truncate cut str to at most n grapheme clusters ending with ellipsis, the ellipsis counts in n
+// exp: Truncate("hello world", 8, "...") -> "hello...", Truncate("👍🏽👍🏽👍🏽", 2, "") -> "👍🏽👍🏽"
+func Truncate(str string, n int, ellipsis string) string {
+	if GraphemeCount(str) <= n {
+		return str
+	}
+	if n <= 0 {
+		return ""
+	}
+	limit := n - GraphemeCount(ellipsis)
+	if limit < 0 {
+		return Truncate(ellipsis, n, "")
+	}
+	return Substr(str, 0, limit) + ellipsis
+}
+
+// PadLeft pad str on the left with pad to width terminal cells
+// exp: PadLeft("42", 5, '0') -> "00042"
+func PadLeft(str string, width int, pad rune) string {
+	return PadWidth(str, width, AlignRight, pad)
+}
+
+// PadRight pad str on the right with pad to width terminal cells
+// exp: PadRight("你好", 6, '.') -> "你好.."
+func PadRight(str string, width int, pad rune) string {
+	return PadWidth(str, width, AlignLeft, pad)
+}
+
+// Center center str in width terminal cells with pad, the extra pad goes to the right
+// exp: Center("hi", 6, '*') -> "**hi**"
+func Center(str string, width int, pad rune) string {
+	return PadWidth(str, width, AlignCenter, pad)
+}
+
+// Wrap wrap str into lines of at most width terminal cells, breaking at spaces,
+// words wider than width are split, existing line breaks are kept
+// exp: Wrap("the quick brown fox", 10) -> "the quick\nbrown fox"
+func Wrap(str string, width int) string {
+	return WrapIndent(str, width, "")
+}
+
+// WrapIndent wrap str like Wrap with a hanging indent: every line but the first of a paragraph
+// start with indent, which counts in the width
+// exp: WrapIndent("-v  enable verbose output", 12, "    ") -> "-v  enable\n    verbose\n    output"
+func WrapIndent(str string, width int, indent string) string {
+	if width <= 0 {
+		return str
+	}
+	paragraphs := strings.Split(str, "\n")
+	for i, p := range paragraphs {
+		paragraphs[i] = wrapParagraph(p, width, indent)
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+func wrapParagraph(str string, width int, indent string) string {
+	indentWidth := DisplayWidth(indent)
+	if indentWidth >= width {
+		indent, indentWidth = "", 0
+	}
+	var lines []string
+	var line strings.Builder
+	lineWidth, empty := 0, true
+	flush := func() {
+		lines = append(lines, strings.TrimRightFunc(line.String(), unicode.IsSpace))
+		line.Reset()
+		line.WriteString(indent)
+		lineWidth, empty = indentWidth, true
+	}
+	for _, tok := range splitSpaces(str) {
+		w := DisplayWidth(tok)
+		space := strings.TrimSpace(tok) == ""
+		if lineWidth+w <= width {
+			line.WriteString(tok)
+			lineWidth += w
+			empty = empty && space
+			continue
+		}
+		if space {
+			// spaces at a line break are dropped
+			flush()
+			continue
+		}
+		if !empty {
+			flush()
+		}
+		for w > width-lineWidth {
+			head := TruncateWidth(tok, width-lineWidth, "")
+			if head == "" {
+				// a single cluster wider than the line
+				head, _ = nextGrapheme(tok)
+			}
+			line.WriteString(head)
+			tok = tok[len(head):]
+			w = DisplayWidth(tok)
+			empty = false
+			flush()
+		}
+		if tok != "" {
+			line.WriteString(tok)
+			lineWidth += w
+			empty = false
+		}
+	}
+	if !empty || len(lines) == 0 {
+		lines = append(lines, strings.TrimRightFunc(line.String(), unicode.IsSpace))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitSpaces split str into alternating runs of spaces and non spaces
+func splitSpaces(str string) []string {
+	var tokens []string
+	start, inSpace := 0, false
+	for i, r := range str {
+		space := unicode.IsSpace(r)
+		if i > start && space != inSpace {
+			tokens = append(tokens, str[start:i])
+			start = i
+		}
+		inSpace = space
+	}
+	if start < len(str) {
+		tokens = append(tokens, str[start:])
+	}
+	return tokens
+}
+
+// Indent add prefix to the beginning of every non blank line of str
+// exp: Indent("a\n\nb", "  ") -> "  a\n\n  b"
+func Indent(str, prefix string) string {
+	lines := strings.Split(str, "\n")
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			lines[i] = prefix + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Dedent remove the longest common leading whitespace of all non blank lines of str,
+// blank lines are emptied
+// exp: Dedent("    a\n      b") -> "a\n  b"
+func Dedent(str string) string {
+	lines := strings.Split(str, "\n")
+	common, found := "", false
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		lead := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+		if !found {
+			common, found = lead, true
+			continue
+		}
+		n := 0
+		for n < len(common) && n < len(lead) && common[n] == lead[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			lines[i] = ""
+		} else {
+			lines[i] = l[len(common):]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,153 @@
+package stringutil
+
+import "testing"
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "hello", want: "olleh"},
+		{in: "你好世界", want: "界世好你"},
+		{in: "héllo👍🏽", want: "👍🏽olléh"},
+		{in: "🇨🇳🇯🇵", want: "🇯🇵🇨🇳"},
+	}
+	for _, tt := range tests {
+		if got := Reverse(tt.in); got != tt.want {
+			t.Fatalf("Reverse(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSubstr(t *testing.T) {
+	tests := []struct {
+		in     string
+		start  int
+		length int
+		want   string
+	}{
+		{in: "你好世界", start: 1, length: 2, want: "好世"},
+		{in: "hello", start: -3, length: -1, want: "llo"},
+		{in: "hello", start: 2, length: 100, want: "llo"},
+		{in: "hello", start: -100, length: 2, want: "he"},
+		{in: "hello", start: 5, length: 1, want: ""},
+		{in: "hello", start: 1, length: 0, want: ""},
+		{in: "a👍🏽b", start: 1, length: 1, want: "👍🏽"},
+	}
+	for _, tt := range tests {
+		if got := Substr(tt.in, tt.start, tt.length); got != tt.want {
+			t.Fatalf("Substr(%q, %d, %d) = %q, want %q", tt.in, tt.start, tt.length, got, tt.want)
+		}
+	}
+}
+
+func TestInsert(t *testing.T) {
+	tests := []struct {
+		in    string
+		index int
+		sub   string
+		want  string
+	}{
+		{in: "你世界", index: 1, sub: "好", want: "你好世界"},
+		{in: "abc", index: 0, sub: "x", want: "xabc"},
+		{in: "abc", index: 10, sub: "x", want: "abcx"},
+		{in: "👍🏽b", index: 1, sub: "a", want: "👍🏽ab"},
+	}
+	for _, tt := range tests {
+		if got := Insert(tt.in, tt.index, tt.sub); got != tt.want {
+			t.Fatalf("Insert(%q, %d, %q) = %q, want %q", tt.in, tt.index, tt.sub, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in       string
+		n        int
+		ellipsis string
+		want     string
+	}{
+		{in: "hello world", n: 8, ellipsis: "...", want: "hello..."},
+		{in: "hello", n: 5, ellipsis: "...", want: "hello"},
+		{in: "👍🏽👍🏽👍🏽", n: 2, ellipsis: "", want: "👍🏽👍🏽"},
+		{in: "你好世界", n: 3, ellipsis: "…", want: "你好…"},
+		{in: "hello", n: 2, ellipsis: "...", want: ".."},
+		{in: "hello", n: 0, ellipsis: "…", want: ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.in, tt.n, tt.ellipsis); got != tt.want {
+			t.Fatalf("Truncate(%q, %d, %q) = %q, want %q", tt.in, tt.n, tt.ellipsis, got, tt.want)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	if got := PadLeft("42", 5, '0'); got != "00042" {
+		t.Fatalf("PadLeft = %q", got)
+	}
+	if got := PadRight("你好", 6, '.'); got != "你好.." {
+		t.Fatalf("PadRight = %q", got)
+	}
+	if got := Center("hi", 7, '*'); got != "**hi***" {
+		t.Fatalf("Center = %q", got)
+	}
+	if got := Center("toolong", 3, '*'); got != "toolong" {
+		t.Fatalf("Center = %q", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		width  int
+		indent string
+		want   string
+	}{
+		{name: "words", in: "the quick brown fox", width: 10, want: "the quick\nbrown fox"},
+		{name: "fits", in: "short", width: 10, want: "short"},
+		{name: "empty", in: "", width: 10, want: ""},
+		{name: "collapseBreakSpaces", in: "aaaa    bbbb", width: 5, want: "aaaa\nbbbb"},
+		{name: "longWord", in: "abcdefghij kl", width: 4, want: "abcd\nefgh\nij\nkl"},
+		{name: "keepNewlines", in: "one two\n\nthree", width: 5, want: "one\ntwo\n\nthree"},
+		{name: "cjk", in: "你好世界你好", width: 5, want: "你好\n世界\n你好"},
+		{name: "emoji", in: "👍🏽👍🏽👍🏽", width: 4, want: "👍🏽👍🏽\n👍🏽"},
+		{name: "hangingIndent", in: "-v  enable verbose output", width: 12, indent: "    ", want: "-v  enable\n    verbose\n    output"},
+		{name: "indentLongWord", in: "x abcdefgh", width: 6, indent: "  ", want: "x\n  abcd\n  efgh"},
+		{name: "noWidth", in: "a b", width: 0, want: "a b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WrapIndent(tt.in, tt.width, tt.indent); got != tt.want {
+				t.Fatalf("WrapIndent(%q, %d, %q) = %q, want %q", tt.in, tt.width, tt.indent, got, tt.want)
+			}
+		})
+	}
+	if got := Wrap("the quick brown fox", 10); got != "the quick\nbrown fox" {
+		t.Fatalf("Wrap = %q", got)
+	}
+}
+
+func TestIndentDedent(t *testing.T) {
+	if got := Indent("a\n\nb", "  "); got != "  a\n\n  b" {
+		t.Fatalf("Indent = %q", got)
+	}
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "    a\n      b", want: "a\n  b"},
+		{in: "\n\t\tx\n  \n\t\ty\n", want: "\nx\n\ny\n"},
+		{in: "  a\n\tb", want: "  a\n\tb"},
+		{in: "no indent", want: "no indent"},
+	}
+	for _, tt := range tests {
+		if got := Dedent(tt.in); got != tt.want {
+			t.Fatalf("Dedent(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := Dedent(Indent("a\n  b", "\t")); got != "a\n  b" {
+		t.Fatalf("Dedent(Indent()) = %q", got)
+	}
+}
@@ -0,0 +1,154 @@
+package stringutil
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// wideRanges are East Asian Wide/Fullwidth code points and emoji presented as two cells
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0},
+	{0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F},
+	{0x2693, 0x2693}, {0x26A1, 0x26A1}, {0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5},
+	{0x26CE, 0x26CE}, {0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x2E80, 0x303E}, {0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19}, {0xFE30, 0xFE6F},
+	{0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4}, {0x17000, 0x18AFF}, {0x1B000, 0x1B2FF},
+	{0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F251},
+	{0x1F300, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F7E0, 0x1F7EB}, {0x1F90C, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+// RuneWidth return the number of terminal cells used by r:
+// 0 for control and combining characters, 2 for CJK and emoji, 1 otherwise
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r < 0x300:
+		return 1
+	case isZeroWidth(r):
+		return 0
+	case isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// DisplayWidth return the number of terminal cells used by str, measured by grapheme cluster
+// exp: "hello" -> 5, "你好" -> 4, "👍🏽" -> 2
+func DisplayWidth(str string) int {
+	width := 0
+	for str != "" {
+		var g string
+		g, str = nextGrapheme(str)
+		width += graphemeWidth(g)
+	}
+	return width
+}
+
+// Align is the alignment of text padded to a width
+type Align int
+
+// alignments of padded text
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// FormatOptions control how FormatWidth fits text to a width
+type FormatOptions struct {
+	// Align is the position of the text in the padded result
+	Align Align
+	// Pad is the rune used for padding, space if zero, it should be one cell wide
+	Pad rune
+	// Truncate cut text wider than the width
+	Truncate bool
+	// Ellipsis is appended to truncated text and counts in the width, exp: "…" or "..."
+	Ellipsis string
+}
+
+// FormatWidth fit str to width terminal cells according to opts
+// exp: FormatWidth("你好世界", 7, FormatOptions{Truncate: true, Ellipsis: "…"}) -> "你好世…"
+func FormatWidth(str string, width int, opts FormatOptions) string {
+	if opts.Truncate {
+		str = TruncateWidth(str, width, opts.Ellipsis)
+	}
+	pad := opts.Pad
+	if pad == 0 {
+		pad = ' '
+	}
+	return PadWidth(str, width, opts.Align, pad)
+}
+
+// PadWidth pad str with pad to width terminal cells, str is returned as is if it is wider,
+// cells left over by a wide pad rune are filled with spaces on the outer side
+// exp: PadWidth("你好", 6, AlignCenter, '*') -> "*你好*", PadWidth("ab", 5, AlignLeft, '中') -> "ab中 "
+func PadWidth(str string, width int, align Align, pad rune) string {
+	gap := width - DisplayWidth(str)
+	if gap <= 0 {
+		return str
+	}
+	switch align {
+	case AlignRight:
+		return padCells(gap, pad, true) + str
+	case AlignCenter:
+		return padCells(gap/2, pad, true) + str + padCells(gap-gap/2, pad, false)
+	}
+	return str + padCells(gap, pad, false)
+}
+
+// padCells fill cells terminal cells with pad, the spaces completing a wide pad rune
+// come first when leading is set so they stay on the outer side
+func padCells(cells int, pad rune, leading bool) string {
+	pw := max(RuneWidth(pad), 1)
+	fill := strings.Repeat(string(pad), cells/pw)
+	spaces := strings.Repeat(" ", cells%pw)
+	if leading {
+		return spaces + fill
+	}
+	return fill + spaces
+}
+
+// TruncateWidth cut str to at most width terminal cells ending with ellipsis, grapheme clusters are never split
+// exp: TruncateWidth("hello world", 8, "...") -> "hello..."
+func TruncateWidth(str string, width int, ellipsis string) string {
+	if DisplayWidth(str) <= width {
+		return str
+	}
+	if width <= 0 {
+		return ""
+	}
+	limit := width - DisplayWidth(ellipsis)
+	if limit < 0 {
+		// not even the ellipsis fits
+		return TruncateWidth(ellipsis, width, "")
+	}
+	used, end := 0, 0
+	for rest := str; rest != ""; {
+		var g string
+		g, rest = nextGrapheme(rest)
+		w := graphemeWidth(g)
+		if used+w > limit {
+			break
+		}
+		used += w
+		end += len(g)
+	}
+	return str[:end] + ellipsis
+}
+
+func isZeroWidth(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || (r >= 0xE0100 && r <= 0xE01EF)
+}
+
+func isWide(r rune) bool {
+	i := sort.Search(len(wideRanges), func(i int) bool { return wideRanges[i][1] >= r })
+	return i < len(wideRanges) && wideRanges[i][0] <= r
+}
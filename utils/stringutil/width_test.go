@@ -0,0 +1,123 @@
+package stringutil
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want int
+	}{
+		{name: "empty", in: "", want: 0},
+		{name: "ascii", in: "hello", want: 5},
+		{name: "cjk", in: "你好", want: 4},
+		{name: "fullwidth", in: "ＡＢ", want: 4},
+		{name: "emoji", in: "ok👍", want: 4},
+		{name: "combining", in: "é", want: 1},
+		{name: "control", in: "a\tb", want: 2},
+		{name: "skinTone", in: "👍🏽", want: 2},
+		{name: "flag", in: "🇨🇳", want: 2},
+		{name: "zwjFamily", in: "👨‍👩‍👧", want: 2},
+		{name: "emojiPresentation", in: "❤️", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayWidth(tt.in); got != tt.want {
+				t.Fatalf("DisplayWidth(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "empty", in: "", want: nil},
+		{name: "ascii", in: "ab", want: []string{"a", "b"}},
+		{name: "combining", in: "e\u0301x", want: []string{"e\u0301", "x"}},
+		{name: "emoji", in: "👍🏽👨‍👩‍👧", want: []string{"👍🏽", "👨‍👩‍👧"}},
+		{name: "flags", in: "🇨🇳🇺🇸🇫", want: []string{"🇨🇳", "🇺🇸", "🇫"}},
+		{name: "crlf", in: "a\r\nb", want: []string{"a", "\r\n", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Graphemes(tt.in)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("Graphemes(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if n := GraphemeCount(tt.in); n != len(tt.want) {
+				t.Fatalf("GraphemeCount(%q) = %d, want %d", tt.in, n, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestFormatWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		width int
+		opts  FormatOptions
+		want  string
+	}{
+		{name: "padLeftAligned", in: "你好", width: 6, want: "你好  "},
+		{name: "padRight", in: "ab", width: 4, opts: FormatOptions{Align: AlignRight}, want: "  ab"},
+		{name: "padCenterRune", in: "你好", width: 7, opts: FormatOptions{Align: AlignCenter, Pad: '*'}, want: "*你好**"},
+		{name: "padWideRuneOddGap", in: "ab", width: 5, opts: FormatOptions{Pad: '中'}, want: "ab中 "},
+		{name: "padWideRuneRight", in: "ab", width: 5, opts: FormatOptions{Align: AlignRight, Pad: '中'}, want: " 中ab"},
+		{name: "padWideRuneCenter", in: "a", width: 6, opts: FormatOptions{Align: AlignCenter, Pad: '中'}, want: "中a中 "},
+		{name: "padWideRuneNarrowGap", in: "abc", width: 4, opts: FormatOptions{Pad: '中'}, want: "abc "},
+		{name: "noTruncate", in: "hello", width: 3, want: "hello"},
+		{name: "truncateEllipsis", in: "你好世界", width: 7, opts: FormatOptions{Truncate: true, Ellipsis: "…"}, want: "你好世…"},
+		{name: "truncateWideBoundary", in: "你好世界", width: 5, opts: FormatOptions{Truncate: true}, want: "你好 "},
+		{name: "truncateKeepCluster", in: "ae\u0301b", width: 2, opts: FormatOptions{Truncate: true}, want: "ae\u0301"},
+		{name: "truncateEmoji", in: "👍🏽👍🏽", width: 3, opts: FormatOptions{Truncate: true, Ellipsis: "."}, want: "👍🏽."},
+		{name: "ellipsisTooWide", in: "hello", width: 2, opts: FormatOptions{Truncate: true, Ellipsis: "..."}, want: ".."},
+		{name: "zeroWidth", in: "hello", width: 0, opts: FormatOptions{Truncate: true, Ellipsis: "…"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatWidth(tt.in, tt.width, tt.opts)
+			if got != tt.want {
+				t.Fatalf("FormatWidth(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+			}
+			if w := DisplayWidth(got); !tt.opts.Truncate && DisplayWidth(tt.in) < tt.width && w != tt.width {
+				t.Fatalf("FormatWidth(%q, %d) is %d cells wide", tt.in, tt.width, w)
+			}
+		})
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		length int
+		align  string
+		want   string
+	}{
+		{name: "left", in: "ab", length: 4, align: "left", want: "ab  "},
+		{name: "right", in: "ab", length: 4, align: "right", want: "  ab"},
+		{name: "center", in: "ab", length: 5, align: "center", want: " ab  "},
+		{name: "cjk", in: "中文", length: 6, align: "left", want: "中文  "},
+		{name: "exact", in: "abcd", length: 4, align: "left", want: "abcd"},
+		{name: "truncate", in: "abcdef", length: 4, align: "left", want: "abcd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatString(tt.in, tt.length, tt.align); got != tt.want {
+				t.Fatalf("FormatString(%q, %d, %q) = %q, want %q", tt.in, tt.length, tt.align, got, tt.want)
+			}
+		})
+	}
+}